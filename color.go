@@ -0,0 +1,200 @@
+package vld
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	hexColorPattern  = regexp.MustCompile(`^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	colorFuncPattern = regexp.MustCompile(`^(rgba?|hsla?)\(\s*(.*?)\s*\)$`)
+)
+
+// Color is a color in the sRGB color space. Alpha ranges from 0 (transparent)
+// to 1 (opaque).
+type Color struct {
+	R uint8
+	G uint8
+	B uint8
+	A float64
+}
+
+// Hex returns the color in hexadecimal notation e.g. "#ff0000", with the
+// alpha channel only when the color isn't opaque e.g. "#ff000080".
+func (c Color) Hex() string {
+	if c.A >= 1 {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, uint8(math.Round(c.A*255)))
+}
+
+// ParseHexColor parses a color in hexadecimal notation: #RGB, #RGBA, #RRGGBB or
+// #RRGGBBAA, in upper or lower case.
+func ParseHexColor(input string) (Color, error) {
+	match := hexColorPattern.FindStringSubmatch(input)
+	if match == nil {
+		return Color{}, fmt.Errorf("invalid hex color '%s'", input)
+	}
+
+	digits := match[1]
+	if len(digits) <= 4 {
+		var expanded strings.Builder
+		for _, digit := range digits {
+			expanded.WriteString(strings.Repeat(string(digit), 2))
+		}
+		digits = expanded.String()
+	}
+
+	components := make([]uint8, 4)
+	components[3] = 255
+	for i := 0; i < len(digits); i += 2 {
+		value, _ := strconv.ParseUint(digits[i:i+2], 16, 8)
+		components[i/2] = uint8(value)
+	}
+
+	return Color{
+		R: components[0],
+		G: components[1],
+		B: components[2],
+		A: float64(components[3]) / 255,
+	}, nil
+}
+
+// ParseColor parses a CSS color in hexadecimal notation (see ParseHexColor) or
+// using the rgb(), rgba(), hsl() and hsla() functions, with either the comma
+// or the space-separated syntax e.g. "rgb(255, 0, 0)", "rgb(255 0 0 / 50%)"
+// or "hsl(120deg 100% 50%)". Function names are matched case-insensitively.
+func ParseColor(input string) (Color, error) {
+	invalid := fmt.Errorf("invalid color '%s'", input)
+	if strings.HasPrefix(input, "#") {
+		return ParseHexColor(input)
+	}
+
+	match := colorFuncPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(input)))
+	if match == nil {
+		return Color{}, invalid
+	}
+
+	arguments, ok := colorArguments(match[2])
+	if !ok {
+		return Color{}, invalid
+	}
+
+	alpha := 1.0
+	if len(arguments) == 4 {
+		if alpha, ok = colorAlpha(arguments[3]); !ok {
+			return Color{}, invalid
+		}
+	}
+
+	var color Color
+	if strings.HasPrefix(match[1], "rgb") {
+		color, ok = rgbColor(arguments[:3])
+	} else {
+		color, ok = hslColor(arguments[:3])
+	}
+
+	if !ok {
+		return Color{}, invalid
+	}
+	color.A = alpha
+	return color, nil
+}
+
+// colorArguments splits the arguments of a CSS color function, which are
+// either separated by commas, or by spaces with the alpha after a slash.
+func colorArguments(input string) ([]string, bool) {
+	var arguments []string
+	if strings.Contains(input, ",") {
+		if strings.Contains(input, "/") {
+			return nil, false
+		}
+
+		for _, argument := range strings.Split(input, ",") {
+			arguments = append(arguments, strings.TrimSpace(argument))
+		}
+	} else {
+		channels, alpha, hasAlpha := strings.Cut(input, "/")
+		arguments = strings.Fields(channels)
+		if hasAlpha {
+			if len(arguments) != 3 {
+				return nil, false
+			}
+			arguments = append(arguments, strings.TrimSpace(alpha))
+		}
+	}
+
+	if len(arguments) != 3 && len(arguments) != 4 {
+		return nil, false
+	}
+	return arguments, true
+}
+
+// colorNumber parses a number, optionally followed by the provided suffix.
+// The returned bool reports whether the suffix was present.
+func colorNumber(input string, suffix string) (float64, bool, bool) {
+	trimmed, hasSuffix := strings.CutSuffix(input, suffix)
+	value, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false, false
+	}
+	return value, hasSuffix, true
+}
+
+// colorAlpha parses an alpha value, either a number from 0 to 1 or a
+// percentage.
+func colorAlpha(input string) (float64, bool) {
+	value, percentage, ok := colorNumber(input, "%")
+	if percentage {
+		value /= 100
+	}
+	return value, ok && value >= 0 && value <= 1
+}
+
+// rgbColor returns the color of the red, green and blue arguments of rgb(),
+// which are either numbers from 0 to 255 or percentages.
+func rgbColor(arguments []string) (Color, bool) {
+	var channels [3]uint8
+	for i, argument := range arguments {
+		value, percentage, ok := colorNumber(argument, "%")
+		if percentage {
+			value = value * 255 / 100
+		}
+
+		if !ok || value < 0 || value > 255 {
+			return Color{}, false
+		}
+		channels[i] = uint8(math.Round(value))
+	}
+	return Color{R: channels[0], G: channels[1], B: channels[2]}, true
+}
+
+// hslColor returns the color of the hue, saturation and lightness arguments
+// of hsl(). The hue is an angle in degrees, while the saturation and the
+// lightness are percentages.
+func hslColor(arguments []string) (Color, bool) {
+	hue, _, okHue := colorNumber(arguments[0], "deg")
+	saturation, percentS, okS := colorNumber(arguments[1], "%")
+	lightness, percentL, okL := colorNumber(arguments[2], "%")
+	if !okHue || !okS || !okL || !percentS || !percentL {
+		return Color{}, false
+	}
+
+	if saturation < 0 || saturation > 100 || lightness < 0 || lightness > 100 {
+		return Color{}, false
+	}
+
+	hue = math.Mod(math.Mod(hue, 360)+360, 360)
+	s, l := saturation/100, lightness/100
+
+	// see https://www.w3.org/TR/css-color-3/#hsl-color
+	channel := func(n float64) uint8 {
+		k := math.Mod(n+hue/30, 12)
+		a := s * math.Min(l, 1-l)
+		return uint8(math.Round(255 * (l - a*math.Max(-1, math.Min(math.Min(k-3, 9-k), 1)))))
+	}
+	return Color{R: channel(0), G: channel(8), B: channel(4)}, true
+}
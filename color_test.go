@@ -0,0 +1,83 @@
+package vld
+
+import "testing"
+
+func TestParseHexColor(t *testing.T) {
+	testCases := map[string]Color{
+		"#f00":      {255, 0, 0, 1},
+		"#F008":     {255, 0, 0, float64(0x88) / 255},
+		"#00ff7F":   {0, 255, 127, 1},
+		"#11223380": {0x11, 0x22, 0x33, float64(0x80) / 255},
+	}
+	for input, expected := range testCases {
+		color, err := ParseHexColor(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if color != expected {
+			t.Errorf("%s: unexpected color %v", input, color)
+			return
+		}
+	}
+
+	for _, input := range []string{"", "f00", "#ff", "#fffff", "#ggg", "#1122334", " #fff"} {
+		if _, err := ParseHexColor(input); err == nil {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	testCases := map[string]Color{
+		"#0f0":                     {0, 255, 0, 1},
+		"rgb(255, 0, 0)":           {255, 0, 0, 1},
+		"RGB(255 128 0)":           {255, 128, 0, 1},
+		"rgba(0, 0, 255, 0.5)":     {0, 0, 255, 0.5},
+		"rgb(0 0 255 / 25%)":       {0, 0, 255, 0.25},
+		"rgb(100%, 50%, 0%)":       {255, 128, 0, 1},
+		"hsl(120, 100%, 50%)":      {0, 255, 0, 1},
+		"hsl(0deg 100% 50% / 0.5)": {255, 0, 0, 0.5},
+		"hsla(240, 100%, 50%, 1)":  {0, 0, 255, 1},
+		"hsl(-120, 100%, 25%)":     {0, 0, 128, 1},
+		"hsl(30 0% 100%)":          {255, 255, 255, 1},
+	}
+	for input, expected := range testCases {
+		color, err := ParseColor(input)
+		if err != nil {
+			t.Errorf("%s: %s", input, err.Error())
+			return
+		}
+
+		if color != expected {
+			t.Errorf("%s: unexpected color %v", input, color)
+			return
+		}
+	}
+
+	invalid := []string{
+		"red", "rgb(256, 0, 0)", "rgb(0, 0)", "rgb(0, 0, 0, 0, 0)", "rgb(0, 0, 0 / 1)",
+		"rgb(0 0 0 / 2)", "rgb(a, b, c)", "hsl(120, 100, 50)", "hsl(120, 100%, 150%)",
+		"rgb(0 0 / 0.5)", "rgb(NaN, 0, 0)",
+	}
+	for _, input := range invalid {
+		if _, err := ParseColor(input); err == nil {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+func TestColorHex(t *testing.T) {
+	if hex := (Color{255, 0, 16, 1}).Hex(); hex != "#ff0010" {
+		t.Errorf("unexpected hex %s", hex)
+		return
+	}
+
+	if hex := (Color{255, 0, 16, 0.5}).Hex(); hex != "#ff001080" {
+		t.Errorf("unexpected hex %s", hex)
+		return
+	}
+}
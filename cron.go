@@ -0,0 +1,227 @@
+package vld
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a recurring schedule, such as a CronSchedule or a
+// RecurrenceRule.
+type Schedule interface {
+	// Next returns the first time the schedule fires after the provided time,
+	// or the zero time when it never fires again.
+	Next(after time.Time) time.Time
+}
+
+// cronField is the set of values a field of a cron expression matches, as a
+// bit set indexed by value.
+type cronField uint64
+
+func (f cronField) has(value int) bool {
+	return f&(1<<uint(value)) != 0
+}
+
+// CronSchedule is a parsed standard 5-field cron expression: minute, hour,
+// day of month, month and day of week.
+type CronSchedule struct {
+	expression string
+	minute     cronField
+	hour       cronField
+	dayOfMonth cronField
+	month      cronField
+	dayOfWeek  cronField
+
+	// when both the day of month and the day of week are restricted, a day
+	// matches when either of them does, as in cron.
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+var cronDayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// ParseCron parses a standard 5-field cron expression, e.g. "*/15 9-17 * *
+// MON-FRI". Fields support lists, ranges, steps, and the names of months and
+// days of the week. The macros @yearly, @annually, @monthly, @weekly, @daily,
+// @midnight and @hourly are supported as well.
+func ParseCron(expression string) (CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) == 1 {
+		if macro, ok := cronMacros[strings.ToLower(fields[0])]; ok {
+			fields = strings.Fields(macro)
+		}
+	}
+
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("cron expression '%s' must have 5 fields", expression)
+	}
+
+	schedule := CronSchedule{
+		expression:    strings.Join(strings.Fields(expression), " "),
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}
+
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.dayOfMonth, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return CronSchedule{}, err
+	}
+
+	// both 0 and 7 are Sunday.
+	if schedule.dayOfWeek, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.dayOfWeek.has(7) {
+		schedule.dayOfWeek |= 1
+	}
+
+	if schedule.anyDayOfWeek && !schedule.dayExists() {
+		return CronSchedule{}, fmt.Errorf("cron expression '%s' never fires", expression)
+	}
+	return schedule, nil
+}
+
+// dayExists reports whether any of the days of month exist in any of the
+// months, e.g. the 30th of February doesn't.
+func (s CronSchedule) dayExists() bool {
+	daysInMonth := []int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+	for month := 1; month <= 12; month++ {
+		if !s.month.has(month) {
+			continue
+		}
+
+		for day := 1; day <= daysInMonth[month]; day++ {
+			if s.dayOfMonth.has(day) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseCronField parses a field of a cron expression, which is a list of
+// "*", values or ranges, each optionally followed by a step e.g. "1-30/5".
+func parseCronField(field string, minimum int, maximum int, names []string) (cronField, error) {
+	var set cronField
+	for _, part := range strings.Split(field, ",") {
+		valueRange, stepText, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in cron field '%s'", field)
+			}
+		}
+
+		low, high := minimum, maximum
+		if valueRange != "*" {
+			lowText, highText, isRange := strings.Cut(valueRange, "-")
+
+			var err error
+			if low, err = parseCronValue(lowText, minimum, maximum, names); err != nil {
+				return 0, fmt.Errorf("invalid cron field '%s': %w", field, err)
+			}
+
+			high = low
+			if isRange {
+				if high, err = parseCronValue(highText, minimum, maximum, names); err != nil {
+					return 0, fmt.Errorf("invalid cron field '%s': %w", field, err)
+				}
+			} else if hasStep {
+				// "5/15" means every 15 starting at 5.
+				high = maximum
+			}
+
+			if high < low {
+				return 0, fmt.Errorf("invalid range in cron field '%s'", field)
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(text string, minimum int, maximum int, names []string) (int, error) {
+	for value, name := range names {
+		if name != "" && strings.EqualFold(text, name) {
+			return value, nil
+		}
+	}
+
+	value, err := strconv.Atoi(text)
+	if err != nil || value < minimum || value > maximum {
+		return 0, fmt.Errorf("value '%s' must be between %d and %d", text, minimum, maximum)
+	}
+	return value, nil
+}
+
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth.has(t.Day())
+	dayOfWeek := s.dayOfWeek.has(int(t.Weekday()))
+
+	switch {
+	case s.anyDayOfMonth && s.anyDayOfWeek:
+		return true
+	case s.anyDayOfMonth:
+		return dayOfWeek
+	case s.anyDayOfWeek:
+		return dayOfMonth
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// Next returns the first time the schedule fires after the provided time, in
+// the location of the provided time.
+func (s CronSchedule) Next(after time.Time) time.Time {
+	location := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+
+	// a matching day exists within every 8 years, unless the day of week is
+	// restricted too, in which case a matching day exists within every year.
+	limit := after.AddDate(9, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !s.month.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+		case !s.hour.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, location)
+		case !s.minute.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s CronSchedule) String() string {
+	return s.expression
+}
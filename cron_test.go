@@ -0,0 +1,51 @@
+package vld
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	for _, expression := range []string{"* * * * *", "*/15 9-17 * * MON-FRI", "0 0 1,15 * *", "5/10 * * jan-mar 7", "@daily", "0 0 29 2 *"} {
+		if _, err := ParseCron(expression); err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+	}
+
+	for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "0 0 30 2 *", "@often"} {
+		if _, err := ParseCron(expression); err == nil {
+			t.Errorf("%s: %s", expression, errInvalidPassed)
+			return
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	from := time.Date(2024, 3, 15, 17, 50, 0, 0, time.UTC) // Friday
+
+	testCases := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{expression: "*/15 9-17 * * MON-FRI", expected: time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)},
+		{expression: "*/15 * * * *", expected: time.Date(2024, 3, 15, 18, 0, 0, 0, time.UTC)},
+		{expression: "@monthly", expected: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 12 29 2 *", expected: time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		// either the 1st of the month or a Sunday.
+		{expression: "0 0 1 * SUN", expected: time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, testCase := range testCases {
+		schedule, err := ParseCron(testCase.expression)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if next := schedule.Next(from); !next.Equal(testCase.expected) {
+			t.Errorf("unexpected next time %s for %s", next, testCase.expression)
+			return
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package vld
+
+import "regexp"
+
+// phoneRegion holds the numbering plan metadata of a single region. Patterns
+// are matched against the national significant number, i.e. the number
+// without the country calling code and national trunk prefix. Regions whose
+// numbering plan doesn't distinguish mobile from fixed-line numbers use the
+// same pattern for both.
+type phoneRegion struct {
+	callingCode string
+	trunkPrefix string
+	mobile      *regexp.Regexp
+	fixedLine   *regexp.Regexp
+}
+
+// anchoredPattern compiles a pattern which must match the whole input.
+func anchoredPattern(pattern string) *regexp.Regexp {
+	return regexp.MustCompile(`^(?:` + pattern + `)$`)
+}
+
+// phoneRegions lists the bundled numbering plans, keyed by ISO 3166-1 alpha-2
+// region code.
+var phoneRegions = map[string]phoneRegion{
+	"AE": {callingCode: "971", trunkPrefix: "0", mobile: anchoredPattern(`5[024568]\d{7}`), fixedLine: anchoredPattern(`[2-4679][2-8]\d{6}`)},
+	"AU": {callingCode: "61", trunkPrefix: "0", mobile: anchoredPattern(`4\d{8}`), fixedLine: anchoredPattern(`[2378]\d{8}`)},
+	"BR": {callingCode: "55", trunkPrefix: "0", mobile: anchoredPattern(`[1-9]{2}9\d{8}`), fixedLine: anchoredPattern(`[1-9]{2}[2-5]\d{7}`)},
+	"CA": {callingCode: "1", trunkPrefix: "1", mobile: anchoredPattern(`[2-9]\d{2}[2-9]\d{6}`), fixedLine: anchoredPattern(`[2-9]\d{2}[2-9]\d{6}`)},
+	"CN": {callingCode: "86", trunkPrefix: "0", mobile: anchoredPattern(`1[3-9]\d{9}`), fixedLine: anchoredPattern(`[2-9]\d{9,10}`)},
+	"DE": {callingCode: "49", trunkPrefix: "0", mobile: anchoredPattern(`1(5\d{9}|[67]\d{8,9})`), fixedLine: anchoredPattern(`[2-9]\d{5,10}`)},
+	"ES": {callingCode: "34", mobile: anchoredPattern(`[67]\d{8}`), fixedLine: anchoredPattern(`[89]\d{8}`)},
+	"FR": {callingCode: "33", trunkPrefix: "0", mobile: anchoredPattern(`[67]\d{8}`), fixedLine: anchoredPattern(`[1-59]\d{8}`)},
+	"GB": {callingCode: "44", trunkPrefix: "0", mobile: anchoredPattern(`7[1-57-9]\d{8}`), fixedLine: anchoredPattern(`(1\d{8,9}|[23]\d{9})`)},
+	"IE": {callingCode: "353", trunkPrefix: "0", mobile: anchoredPattern(`8[3-9]\d{7}`), fixedLine: anchoredPattern(`(1\d{7,8}|[2-79]\d{6,8})`)},
+	"IN": {callingCode: "91", trunkPrefix: "0", mobile: anchoredPattern(`[6-9]\d{9}`), fixedLine: anchoredPattern(`[1-5]\d{9}`)},
+	"IT": {callingCode: "39", mobile: anchoredPattern(`3\d{8,9}`), fixedLine: anchoredPattern(`0\d{5,10}`)},
+	"JP": {callingCode: "81", trunkPrefix: "0", mobile: anchoredPattern(`[789]0\d{8}`), fixedLine: anchoredPattern(`[1-9]\d{8}`)},
+	"MX": {callingCode: "52", mobile: anchoredPattern(`[1-9]\d{9}`), fixedLine: anchoredPattern(`[1-9]\d{9}`)},
+	"NG": {callingCode: "234", trunkPrefix: "0", mobile: anchoredPattern(`[7-9][01]\d{8}`), fixedLine: anchoredPattern(`[1-9]\d{6,7}`)},
+	"NL": {callingCode: "31", trunkPrefix: "0", mobile: anchoredPattern(`6[1-58]\d{7}`), fixedLine: anchoredPattern(`[1-57]\d{8}`)},
+	"PK": {callingCode: "92", trunkPrefix: "0", mobile: anchoredPattern(`3[0-6]\d{8}`), fixedLine: anchoredPattern(`[2-9]\d{7,9}`)},
+	"SG": {callingCode: "65", mobile: anchoredPattern(`[89]\d{7}`), fixedLine: anchoredPattern(`6\d{7}`)},
+	"US": {callingCode: "1", trunkPrefix: "1", mobile: anchoredPattern(`[2-9]\d{2}[2-9]\d{6}`), fixedLine: anchoredPattern(`[2-9]\d{2}[2-9]\d{6}`)},
+	"ZA": {callingCode: "27", trunkPrefix: "0", mobile: anchoredPattern(`[6-8]\d{8}`), fixedLine: anchoredPattern(`[1-5]\d{8}`)},
+}
@@ -0,0 +1,49 @@
+package vld
+
+import "regexp"
+
+// postalFormat describes the postal codes of a single country. The pattern is
+// matched against the compact form of the code, i.e. upper-case without spaces
+// or dashes. The separator is inserted at separatorAt (counted from the end
+// when negative) to produce the normalized output, as long as the compact code
+// is longer than that position.
+type postalFormat struct {
+	pattern     *regexp.Regexp
+	separatorAt int
+	separator   string
+}
+
+// postalFormats lists the bundled postal code formats, keyed by ISO 3166-1
+// alpha-2 country code.
+var postalFormats = map[string]postalFormat{
+	"AT": {pattern: anchoredPattern(`[1-9]\d{3}`)},
+	"AU": {pattern: anchoredPattern(`\d{4}`)},
+	"BE": {pattern: anchoredPattern(`[1-9]\d{3}`)},
+	"BR": {pattern: anchoredPattern(`\d{8}`), separatorAt: 5, separator: "-"},
+	"CA": {pattern: anchoredPattern(`[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z]\d[ABCEGHJ-NPRSTV-Z]\d`), separatorAt: 3, separator: " "},
+	"CH": {pattern: anchoredPattern(`[1-9]\d{3}`)},
+	"CN": {pattern: anchoredPattern(`\d{6}`)},
+	"DE": {pattern: anchoredPattern(`\d{5}`)},
+	"DK": {pattern: anchoredPattern(`[1-9]\d{3}`)},
+	"ES": {pattern: anchoredPattern(`(0[1-9]|[1-4]\d|5[0-2])\d{3}`)},
+	"FI": {pattern: anchoredPattern(`\d{5}`)},
+	"FR": {pattern: anchoredPattern(`\d{5}`)},
+	"GB": {pattern: anchoredPattern(`(GIR0AA|[A-PR-UWYZ]([0-9]{1,2}|[A-HK-Y][0-9]{1,2}|[0-9][A-HJKS-UW]|[A-HK-Y][0-9][ABEHMNPRV-Y])[0-9][ABD-HJLNP-UW-Z]{2})`), separatorAt: -3, separator: " "},
+	"IE": {pattern: anchoredPattern(`([AC-FHKNPRTV-Y]\d{2}|D6W)[0-9AC-FHKNPRTV-Y]{4}`), separatorAt: 3, separator: " "},
+	"IN": {pattern: anchoredPattern(`[1-9]\d{5}`)},
+	"IT": {pattern: anchoredPattern(`\d{5}`)},
+	"JP": {pattern: anchoredPattern(`\d{7}`), separatorAt: 3, separator: "-"},
+	"KR": {pattern: anchoredPattern(`\d{5}`)},
+	"MX": {pattern: anchoredPattern(`\d{5}`)},
+	"NL": {pattern: anchoredPattern(`[1-9]\d{3}([A-RT-Z][A-Z]|S[BCE-RT-Z])`), separatorAt: 4, separator: " "},
+	"NO": {pattern: anchoredPattern(`\d{4}`)},
+	"NZ": {pattern: anchoredPattern(`\d{4}`)},
+	"PK": {pattern: anchoredPattern(`\d{5}`)},
+	"PL": {pattern: anchoredPattern(`\d{5}`), separatorAt: 2, separator: "-"},
+	"PT": {pattern: anchoredPattern(`[1-9]\d{6}`), separatorAt: 4, separator: "-"},
+	"RU": {pattern: anchoredPattern(`\d{6}`)},
+	"SE": {pattern: anchoredPattern(`[1-9]\d{4}`), separatorAt: 3, separator: " "},
+	"SG": {pattern: anchoredPattern(`\d{6}`)},
+	"US": {pattern: anchoredPattern(`\d{5}(\d{4})?`), separatorAt: 5, separator: "-"},
+	"ZA": {pattern: anchoredPattern(`\d{4}`)},
+}
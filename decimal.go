@@ -0,0 +1,148 @@
+package vld
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number, e.g. a price. It is stored as an
+// unscaled integer and a scale, i.e. 12.50 is stored as 1250 with a scale of
+// 2, so no precision is lost the way it is with float64.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// decimalMaxDigits bounds the number of digits of the mantissa of a decimal,
+// to keep huge inputs from allocating, and being processed as, huge numbers.
+const decimalMaxDigits = 1000
+
+// ParseDecimal parses a decimal number such as "-12.50" or "1.5e3". Trailing
+// zeros of the fraction are kept, i.e. "12.50" has a scale of 2. Mantissas
+// are limited to 1000 digits.
+func ParseDecimal(input string) (Decimal, error) {
+	invalid := fmt.Errorf("invalid decimal number '%s'", input)
+
+	mantissa, exponent := input, 0
+	if i := strings.IndexAny(input, "eE"); i != -1 {
+		var err error
+		mantissa = input[:i]
+		// the exponent is bounded to keep huge inputs such as "1e999999999"
+		// from allocating huge numbers.
+		if exponent, err = strconv.Atoi(input[i+1:]); err != nil || exponent > 1000 || exponent < -1000 {
+			return Decimal{}, invalid
+		}
+	}
+
+	sign := ""
+	if strings.HasPrefix(mantissa, "-") || strings.HasPrefix(mantissa, "+") {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(mantissa, ".")
+	if (integer == "" && fraction == "") || (integer != "" && !isDigits(integer)) || (fraction != "" && !isDigits(fraction)) {
+		return Decimal{}, invalid
+	}
+
+	if len(integer)+len(fraction) > decimalMaxDigits {
+		return Decimal{}, invalid
+	}
+
+	unscaled, ok := new(big.Int).SetString(sign+integer+fraction, 10)
+	if !ok {
+		return Decimal{}, invalid
+	}
+
+	scale := len(fraction) - exponent
+	if scale < 0 {
+		unscaled.Mul(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-scale)), nil))
+		scale = 0
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+func (d Decimal) unscaledValue() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Rat returns the decimal as an exact rational number.
+func (d Decimal) Rat() *big.Rat {
+	denominator := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(d.unscaledValue(), denominator)
+}
+
+// Cmp returns -1, 0 or 1 depending on whether d is less than, equal to or
+// greater than other. The scale is ignored, i.e. 1.5 equals 1.50.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.unscaledValue()).String()
+	sign := ""
+	if d.unscaledValue().Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.scale == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+}
+
+// trimmed returns the decimal without the trailing zeros of its fraction, i.e.
+// with the smallest scale which represents the same value.
+func (d Decimal) trimmed() Decimal {
+	if d.unscaledValue().Sign() == 0 {
+		return Decimal{unscaled: new(big.Int), scale: 0}
+	}
+
+	// the trailing zeros are counted once, and removed with a single division,
+	// rather than dividing by 10 once per zero.
+	digits := d.unscaledValue().String()
+	zeros := len(digits) - len(strings.TrimRight(digits, "0"))
+	zeros = min(zeros, d.scale)
+	if zeros == 0 {
+		return Decimal{unscaled: new(big.Int).Set(d.unscaledValue()), scale: d.scale}
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(zeros)), nil)
+	return Decimal{unscaled: new(big.Int).Quo(d.unscaledValue(), divisor), scale: d.scale - zeros}
+}
+
+// digits returns the number of digits of the decimal, both before and after
+// the decimal point, the same way as the precision of a SQL DECIMAL column
+// i.e. 12.50 and 0.05 have 4 and 2 digits.
+func (d Decimal) digits() int {
+	digits := len(new(big.Int).Abs(d.unscaledValue()).String())
+	if d.unscaledValue().Sign() == 0 {
+		digits = 0
+	}
+	return max(digits, d.scale)
+}
+
+// withScale returns the decimal with its scale increased to the provided one
+// e.g. 12.5 with a scale of 2 is 12.50. The scale is never decreased.
+func (d Decimal) withScale(scale int) Decimal {
+	if scale <= d.scale {
+		return d
+	}
+
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil)
+	return Decimal{unscaled: new(big.Int).Mul(d.unscaledValue(), factor), scale: scale}
+}
@@ -0,0 +1,71 @@
+package vld
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		scale    int
+	}{
+		{input: "12.50", expected: "12.50", scale: 2},
+		{input: "-0.05", expected: "-0.05", scale: 2},
+		{input: "+7", expected: "7", scale: 0},
+		{input: ".5", expected: "0.5", scale: 1},
+		{input: "1.5e3", expected: "1500", scale: 0},
+		{input: "15e-3", expected: "0.015", scale: 3},
+	}
+
+	for _, testCase := range testCases {
+		decimal, err := ParseDecimal(testCase.input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if decimal.String() != testCase.expected || decimal.Scale() != testCase.scale {
+			t.Errorf("unexpected decimal %s (scale %d) for %s", decimal, decimal.Scale(), testCase.input)
+			return
+		}
+	}
+
+	tooLong := "1." + strings.Repeat("0", decimalMaxDigits)
+	for _, input := range []string{"", "-", ".", "abc", "1e9999", "0x10", tooLong} {
+		if _, err := ParseDecimal(input); err == nil {
+			t.Errorf("%s: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	a, _ := ParseDecimal("1.5")
+	b, _ := ParseDecimal("1.50")
+	c, _ := ParseDecimal("-2")
+
+	if a.Cmp(b) != 0 || a.Cmp(c) != 1 || c.Cmp(a) != -1 {
+		t.Error("decimals must be compared by value")
+		return
+	}
+}
+
+func TestDecimalTrimmed(t *testing.T) {
+	testCases := map[string]string{
+		"12.500": "12.5",
+		"-1.50":  "-1.5",
+		"1.000":  "1",
+		"100":    "100",
+		"0.00":   "0",
+		"1." + strings.Repeat("0", decimalMaxDigits-1): "1",
+	}
+	for input, expected := range testCases {
+		decimal, _ := ParseDecimal(input)
+		if decimal.trimmed().String() != expected {
+			t.Errorf("unexpected trimmed decimal %s for %s", decimal.trimmed(), input)
+			return
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package vld
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// earthRadius is the mean radius of the Earth, in kilometers.
+const earthRadius = 6371.0088
+
+// GeoPoint is a point on the Earth, in decimal degrees.
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// valid reports whether the latitude and longitude of the point are within
+// their ranges.
+func (p GeoPoint) valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// Distance returns the great-circle distance between two points in kilometers,
+// using the haversine formula.
+func (p GeoPoint) Distance(other GeoPoint) float64 {
+	lat1, lat2 := p.Lat*math.Pi/180, other.Lat*math.Pi/180
+	deltaLat := lat2 - lat1
+	deltaLng := (other.Lng - p.Lng) * math.Pi / 180
+
+	h := math.Pow(math.Sin(deltaLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(deltaLng/2), 2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox is an area between two latitudes and two longitudes. A box
+// whose west longitude is greater than its east longitude crosses the
+// antimeridian, e.g. West: 170, East: -170.
+type BoundingBox struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// valid reports whether the box is made of valid coordinates, with its south
+// latitude below its north latitude.
+func (box BoundingBox) valid() bool {
+	return GeoPoint{box.South, box.West}.valid() && GeoPoint{box.North, box.East}.valid() && box.South <= box.North
+}
+
+// Contains reports whether the provided point is within the box, including
+// its edges.
+func (box BoundingBox) Contains(p GeoPoint) bool {
+	if p.Lat < box.South || p.Lat > box.North {
+		return false
+	}
+
+	if box.West <= box.East {
+		return p.Lng >= box.West && p.Lng <= box.East
+	}
+	return p.Lng >= box.West || p.Lng <= box.East
+}
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// DecodeGeohash decodes a geohash of up to 12 characters, e.g. "u4pruydqqvj",
+// into the center of the area it describes. Geohashes are matched
+// case-insensitively.
+func DecodeGeohash(input string) (GeoPoint, error) {
+	if input == "" || len(input) > 12 {
+		return GeoPoint{}, fmt.Errorf("invalid geohash '%s'", input)
+	}
+
+	south, north := -90.0, 90.0
+	west, east := -180.0, 180.0
+	even := true
+	for _, char := range strings.ToLower(input) {
+		value := strings.IndexRune(geohashAlphabet, char)
+		if value == -1 {
+			return GeoPoint{}, fmt.Errorf("invalid geohash '%s'", input)
+		}
+
+		// bits alternate between longitude and latitude, starting with the
+		// longitude.
+		for bit := 4; bit >= 0; bit-- {
+			set := value&(1<<bit) != 0
+			if even {
+				middle := (west + east) / 2
+				if set {
+					west = middle
+				} else {
+					east = middle
+				}
+			} else {
+				middle := (south + north) / 2
+				if set {
+					south = middle
+				} else {
+					north = middle
+				}
+			}
+			even = !even
+		}
+	}
+	return GeoPoint{Lat: (south + north) / 2, Lng: (west + east) / 2}, nil
+}
@@ -0,0 +1,76 @@
+package vld
+
+import (
+	"math"
+	"testing"
+)
+
+// closeTo reports whether two points are within the provided number of
+// degrees of each other.
+func closeTo(a GeoPoint, b GeoPoint, degrees float64) bool {
+	return math.Abs(a.Lat-b.Lat) <= degrees && math.Abs(a.Lng-b.Lng) <= degrees
+}
+
+func TestDistance(t *testing.T) {
+	london := GeoPoint{Lat: 51.5074, Lng: -0.1278}
+	paris := GeoPoint{Lat: 48.8566, Lng: 2.3522}
+	if distance := london.Distance(paris); math.Abs(distance-343.5) > 1 {
+		t.Errorf("unexpected distance %f", distance)
+		return
+	}
+
+	if distance := london.Distance(london); distance != 0 {
+		t.Errorf("unexpected distance %f", distance)
+		return
+	}
+
+	// across the antimeridian
+	if distance := (GeoPoint{0, 179.5}).Distance(GeoPoint{0, -179.5}); math.Abs(distance-111.2) > 1 {
+		t.Errorf("unexpected distance %f", distance)
+		return
+	}
+}
+
+func TestBoundingBoxContains(t *testing.T) {
+	box := BoundingBox{South: 40, West: -75, North: 41, East: -73}
+	if !box.Contains(GeoPoint{40.7, -74}) || !box.Contains(GeoPoint{41, -73}) {
+		t.Error("expected the point to be within the box")
+		return
+	}
+
+	if box.Contains(GeoPoint{42, -74}) || box.Contains(GeoPoint{40.7, -72}) {
+		t.Error("expected the point to be outside of the box")
+		return
+	}
+
+	fiji := BoundingBox{South: -21, West: 176, North: -12, East: -178}
+	if !fiji.Contains(GeoPoint{-17, 179}) || !fiji.Contains(GeoPoint{-17, -179}) || fiji.Contains(GeoPoint{-17, 0}) {
+		t.Error("unexpected result for a box crossing the antimeridian")
+		return
+	}
+}
+
+func TestDecodeGeohash(t *testing.T) {
+	point, err := DecodeGeohash("u4pruydqqvj")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !closeTo(point, GeoPoint{57.64911, 10.40744}, 0.00001) {
+		t.Errorf("unexpected point %v", point)
+		return
+	}
+
+	if point, _ := DecodeGeohash("EZS42"); !closeTo(point, GeoPoint{42.6, -5.6}, 0.05) {
+		t.Errorf("unexpected point %v", point)
+		return
+	}
+
+	for _, input := range []string{"", "u4pa", "u4pruydqqvjzz", "u4pr ydqq"} {
+		if _, err := DecodeGeohash(input); err == nil {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
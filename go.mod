@@ -1,3 +1,10 @@
 module github.com/moeenn/vld
 
 go 1.22.0
+
+require (
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/net v0.35.0
+	golang.org/x/text v0.22.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,121 @@
+package vld
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// ISODuration is an ISO 8601 duration such as P1DT2H. Unlike time.Duration,
+// years, months and days don't have a fixed length, which is why the length of
+// the duration depends on the time it is added to.
+type ISODuration struct {
+	Years   int
+	Months  int
+	Weeks   int
+	Days    int
+	Hours   int
+	Minutes int
+	Seconds float64
+}
+
+// ParseISODuration parses an ISO 8601 duration, e.g. "P1Y2M", "P2W" or
+// "PT1.5S". Only the seconds may have a fraction. Durations which may not fit
+// in a time.Duration, i.e. about 292 years, are rejected, counting years as
+// 366 days and months as 31 days.
+func ParseISODuration(input string) (ISODuration, error) {
+	invalid := fmt.Errorf("invalid ISO 8601 duration '%s'", input)
+
+	match := isoDurationPattern.FindStringSubmatch(input)
+	if match == nil || input == "P" || strings.HasSuffix(input, "T") {
+		return ISODuration{}, invalid
+	}
+
+	var components [6]int
+	for i := range components {
+		if match[i+1] == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return ISODuration{}, invalid
+		}
+		components[i] = value
+	}
+
+	var seconds float64
+	if match[7] != "" {
+		var err error
+		if seconds, err = strconv.ParseFloat(strings.Replace(match[7], ",", ".", 1), 64); err != nil {
+			return ISODuration{}, invalid
+		}
+	}
+
+	// the length is computed in seconds as a float64, which can't overflow.
+	days := float64(components[0])*366 + float64(components[1])*31 + float64(components[2])*7 + float64(components[3])
+	length := days*24*60*60 + float64(components[4])*60*60 + float64(components[5])*60 + seconds
+	if length >= float64(math.MaxInt64)/float64(time.Second) {
+		return ISODuration{}, invalid
+	}
+
+	return ISODuration{
+		Years:   components[0],
+		Months:  components[1],
+		Weeks:   components[2],
+		Days:    components[3],
+		Hours:   components[4],
+		Minutes: components[5],
+		Seconds: seconds,
+	}, nil
+}
+
+// AddTo returns the provided time plus the duration. Years, months, weeks and
+// days are added to the calendar date, the same way as time.AddDate.
+func (d ISODuration) AddTo(t time.Time) time.Time {
+	t = t.AddDate(d.Years, d.Months, d.Weeks*7+d.Days)
+	return t.Add(time.Duration(d.Hours)*time.Hour + time.Duration(d.Minutes)*time.Minute + time.Duration(d.Seconds*float64(time.Second)))
+}
+
+// Duration returns the length of the duration when added to the provided
+// time.
+func (d ISODuration) Duration(from time.Time) time.Duration {
+	return d.AddTo(from).Sub(from)
+}
+
+func (d ISODuration) String() string {
+	var builder strings.Builder
+	builder.WriteString("P")
+
+	for _, component := range []struct {
+		value int
+		unit  string
+	}{{d.Years, "Y"}, {d.Months, "M"}, {d.Weeks, "W"}, {d.Days, "D"}} {
+		if component.value != 0 {
+			fmt.Fprintf(&builder, "%d%s", component.value, component.unit)
+		}
+	}
+
+	if d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0 {
+		builder.WriteString("T")
+		if d.Hours != 0 {
+			fmt.Fprintf(&builder, "%dH", d.Hours)
+		}
+		if d.Minutes != 0 {
+			fmt.Fprintf(&builder, "%dM", d.Minutes)
+		}
+		if d.Seconds != 0 {
+			builder.WriteString(strconv.FormatFloat(d.Seconds, 'f', -1, 64) + "S")
+		}
+	}
+
+	if builder.Len() == 1 {
+		return "PT0S"
+	}
+	return builder.String()
+}
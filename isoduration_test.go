@@ -0,0 +1,47 @@
+package vld
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISODuration(t *testing.T) {
+	testCases := map[string]ISODuration{
+		"P1DT2H":         {Days: 1, Hours: 2},
+		"P1Y2M":          {Years: 1, Months: 2},
+		"P2W":            {Weeks: 2},
+		"PT30M":          {Minutes: 30},
+		"PT1.5S":         {Seconds: 1.5},
+		"P1Y2M3DT4H5M6S": {Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6},
+	}
+
+	for input, expected := range testCases {
+		duration, err := ParseISODuration(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if duration != expected || duration.String() != input {
+			t.Errorf("unexpected duration %s for %s", duration, input)
+			return
+		}
+	}
+
+	for _, input := range []string{"", "P", "PT", "1D", "P1H", "PT1D", "P1.5D", "P-1D", "p1d", "PT3000000H", "PT9999999999999S", "P300Y"} {
+		if _, err := ParseISODuration(input); err == nil {
+			t.Errorf("%s: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+func TestISODurationAddTo(t *testing.T) {
+	duration, _ := ParseISODuration("P1M")
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	if duration.Duration(from) != 29*24*time.Hour {
+		t.Errorf("unexpected duration %s", duration.Duration(from))
+		return
+	}
+}
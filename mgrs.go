@@ -0,0 +1,117 @@
+package vld
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var mgrsPattern = regexp.MustCompile(`^(\d{1,2})([C-HJ-NP-X])([A-HJ-NP-Z])([A-HJ-NP-V])(\d*)$`)
+
+// mgrsMinNorthing holds the lowest UTM northing of each latitude band, rounded
+// down to 100 km.
+var mgrsMinNorthing = map[byte]float64{
+	'C': 1100000, 'D': 2000000, 'E': 2800000, 'F': 3700000, 'G': 4600000,
+	'H': 5500000, 'J': 6400000, 'K': 7300000, 'L': 8200000, 'M': 9100000,
+	'N': 0, 'P': 800000, 'Q': 1700000, 'R': 2600000, 'S': 3500000,
+	'T': 4400000, 'U': 5300000, 'V': 6200000, 'W': 7000000, 'X': 7900000,
+}
+
+// mgrsColumns holds the column letters of the 100 km squares, which repeat
+// every three zones.
+var mgrsColumns = []string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+
+const mgrsRows = "ABCDEFGHJKLMNPQRSTUV"
+
+// ParseMGRS decodes a Military Grid Reference System coordinate, e.g.
+// "18SUJ2338308450" or "18S UJ 23383 08450", into the center of the grid
+// square it describes. The polar regions, which use the UPS grid, aren't
+// supported.
+func ParseMGRS(input string) (GeoPoint, error) {
+	invalid := fmt.Errorf("invalid MGRS coordinate '%s'", input)
+
+	match := mgrsPattern.FindStringSubmatch(strings.ToUpper(strings.Join(strings.Fields(input), "")))
+	if match == nil || len(match[5])%2 != 0 || len(match[5]) > 10 {
+		return GeoPoint{}, invalid
+	}
+
+	zone, _ := strconv.Atoi(match[1])
+	if zone < 1 || zone > 60 {
+		return GeoPoint{}, invalid
+	}
+
+	set := (zone - 1) % 3
+	column := strings.Index(mgrsColumns[set], match[3])
+	row := strings.Index(mgrsRows, match[4])
+	if column == -1 {
+		return GeoPoint{}, invalid
+	}
+
+	// rows of even zones start 5 letters further.
+	if zone%2 == 0 {
+		row = (row + 15) % 20
+	}
+
+	precision := len(match[5]) / 2
+	easting, northing := 0.0, 0.0
+	if precision != 0 {
+		e, _ := strconv.Atoi(match[5][:precision])
+		n, _ := strconv.Atoi(match[5][precision:])
+		easting, northing = float64(e), float64(n)
+	}
+
+	unit := math.Pow(10, float64(5-precision))
+	band := match[2][0]
+	easting = float64(column+1)*100000 + easting*unit + unit/2
+	northing = float64(row)*100000 + northing*unit + unit/2
+	for northing < mgrsMinNorthing[band] {
+		northing += 2000000
+	}
+
+	point := utmToGeoPoint(zone, band < 'N', easting, northing)
+	if !point.valid() {
+		return GeoPoint{}, invalid
+	}
+	return point, nil
+}
+
+// utmToGeoPoint converts WGS 84 UTM coordinates to a point, using the series
+// of Snyder's "Map Projections: A Working Manual".
+func utmToGeoPoint(zone int, southern bool, easting float64, northing float64) GeoPoint {
+	const (
+		k0 = 0.9996
+		a  = 6378137.0
+		e2 = 0.00669438
+	)
+	ePrime2 := e2 / (1 - e2)
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+
+	x := easting - 500000
+	y := northing
+	if southern {
+		y -= 10000000
+	}
+
+	mu := y / k0 / (a * (1 - e2/4 - 3*e2*e2/64 - 5*e2*e2*e2/256))
+	phi1 := mu + (3*e1/2-27*math.Pow(e1, 3)/32)*math.Sin(2*mu) +
+		(21*e1*e1/16-55*math.Pow(e1, 4)/32)*math.Sin(4*mu) +
+		(151*math.Pow(e1, 3)/96)*math.Sin(6*mu)
+
+	sin, cos, tan := math.Sin(phi1), math.Cos(phi1), math.Tan(phi1)
+	n1 := a / math.Sqrt(1-e2*sin*sin)
+	t1 := tan * tan
+	c1 := ePrime2 * cos * cos
+	r1 := a * (1 - e2) / math.Pow(1-e2*sin*sin, 1.5)
+	d := x / (n1 * k0)
+
+	lat := phi1 - (n1*tan/r1)*(d*d/2-
+		(5+3*t1+10*c1-4*c1*c1-9*ePrime2)*math.Pow(d, 4)/24+
+		(61+90*t1+298*c1+45*t1*t1-252*ePrime2-3*c1*c1)*math.Pow(d, 6)/720)
+	lng := (d - (1+2*t1+c1)*math.Pow(d, 3)/6 +
+		(5-2*c1+28*t1-3*c1*c1+8*ePrime2+24*t1*t1)*math.Pow(d, 5)/120) / cos
+
+	origin := float64((zone-1)*6 - 180 + 3)
+	return GeoPoint{Lat: lat * 180 / math.Pi, Lng: origin + lng*180/math.Pi}
+}
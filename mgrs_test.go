@@ -0,0 +1,32 @@
+package vld
+
+import "testing"
+
+func TestParseMGRS(t *testing.T) {
+	testCases := map[string]GeoPoint{
+		"33UXP04":         {48.2495, 16.4144},
+		"33uxp 0 4":       {48.2495, 16.4144},
+		"24XWT783908":     {83.6278, -32.6643},
+		"31NAA6602100000": {0, 0},
+	}
+	for input, expected := range testCases {
+		point, err := ParseMGRS(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if !closeTo(point, expected, 0.0001) {
+			t.Errorf("%s: unexpected point %v", input, point)
+			return
+		}
+	}
+
+	invalid := []string{"", "33UXP0", "33UXP123", "61UXP04", "0UXP04", "33IXP04", "33UIP04", "33UXW04", "33UXP04123456789012", "ZZ"}
+	for _, input := range invalid {
+		if _, err := ParseMGRS(input); err == nil {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
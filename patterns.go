@@ -2,7 +2,16 @@ package vld
 
 const (
 	PATTERN_EMAIL = `^[^@]+@[^@]+\.[^@]+$`
-	PATTERN_UUID  = `^[a-f\d]{8}(-[a-f\d]{4}){4}[a-f\d]{8}$`
+	PATTERN_UUID  = `(?i)^[a-f\d]{8}(-[a-f\d]{4}){3}-[a-f\d]{12}$`
+	PATTERN_BIC   = `^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`
+	PATTERN_ULID  = `(?i)^[0-7][0-9A-HJKMNP-TV-Z]{25}$`
+	PATTERN_KSUID = `^[0-9A-Za-z]{27}$`
+
+	// Semantic version as defined by https://semver.org. Capture groups:
+	// major, minor, patch, pre-release, build metadata.
+	PATTERN_SEMVER = `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
 
 	// Password strength rules:
 	// - Minimum eight characters
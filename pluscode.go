@@ -0,0 +1,69 @@
+package vld
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	plusCodeAlphabet  = "23456789CFGHJMPQRVWX"
+	plusCodeSeparator = 8
+	plusCodeMaxDigits = 15
+)
+
+// ParsePlusCode decodes a full Open Location Code (plus code), e.g.
+// "8FVC9G8F+6X", into the center of the area it describes. Codes are matched
+// case-insensitively. Short codes such as "9G8F+6X" can't be decoded without a
+// reference location, and are rejected.
+func ParsePlusCode(input string) (GeoPoint, error) {
+	invalid := fmt.Errorf("invalid plus code '%s'", input)
+
+	code := strings.ToUpper(input)
+	if strings.Count(code, "+") != 1 || strings.Index(code, "+") != plusCodeSeparator {
+		return GeoPoint{}, invalid
+	}
+
+	digits := strings.Replace(code, "+", "", 1)
+	if len(code) == plusCodeSeparator+2 || len(digits) > plusCodeMaxDigits {
+		return GeoPoint{}, invalid
+	}
+
+	// padding is only allowed in pairs, right before the separator.
+	if padding := strings.Index(digits, "0"); padding != -1 {
+		if padding == 0 || padding%2 != 0 || len(digits) != plusCodeSeparator || strings.TrimRight(digits, "0") != digits[:padding] {
+			return GeoPoint{}, invalid
+		}
+		digits = digits[:padding]
+	}
+
+	lat, lng := -90.0, -180.0
+	latStep, lngStep := 400.0, 400.0
+	for i, char := range digits {
+		value := strings.IndexRune(plusCodeAlphabet, char)
+		if value == -1 {
+			return GeoPoint{}, invalid
+		}
+
+		switch {
+		case i < 10 && i%2 == 0:
+			latStep /= 20
+			lat += float64(value) * latStep
+		case i < 10:
+			lngStep /= 20
+			lng += float64(value) * lngStep
+		default:
+			// digits after the first ten refine a grid of 5 rows and 4
+			// columns.
+			latStep /= 5
+			lngStep /= 4
+			lat += float64(value/4) * latStep
+			lng += float64(value%4) * lngStep
+		}
+	}
+
+	if lat >= 90 || lng >= 180 {
+		return GeoPoint{}, invalid
+	}
+	return GeoPoint{Lat: math.Min(lat+latStep/2, 90), Lng: lng + lngStep/2}, nil
+}
@@ -0,0 +1,36 @@
+package vld
+
+import "testing"
+
+func TestParsePlusCode(t *testing.T) {
+	testCases := map[string]GeoPoint{
+		"7FG49Q00+":   {20.375, 2.775},
+		"7FG49QCJ+2V": {20.3700625, 2.7821875},
+		"7fg49qcj+2v": {20.3700625, 2.7821875},
+		"8FVC9G8F+6X": {47.3655625, 8.5249375},
+		"CFX30000+":   {89.5, 1.5},
+	}
+	for input, expected := range testCases {
+		point, err := ParsePlusCode(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if !closeTo(point, expected, 0.000001) {
+			t.Errorf("%s: unexpected point %v", input, point)
+			return
+		}
+	}
+
+	invalid := []string{
+		"", "9G8F+6X", "8FVC9G8F6X", "8FVC9G8F+6", "8FVC9G8F+6X+", "8FVC9G00+6X",
+		"8FVC9G0F+", "8FVC9G8A+6X", "F2000000+", "8W000000+", "8FVC9G8F+6XXXXXXXX",
+	}
+	for _, input := range invalid {
+		if _, err := ParsePlusCode(input); err == nil {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
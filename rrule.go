@@ -0,0 +1,634 @@
+package vld
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	RRULE_YEARLY   = "YEARLY"
+	RRULE_MONTHLY  = "MONTHLY"
+	RRULE_WEEKLY   = "WEEKLY"
+	RRULE_DAILY    = "DAILY"
+	RRULE_HOURLY   = "HOURLY"
+	RRULE_MINUTELY = "MINUTELY"
+)
+
+var rruleDayNames = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// rruleMaxYears bounds the time searched for the next occurrence of a rule,
+// from its start or the provided time, whichever is later. Rules which don't
+// fire within it are considered to never fire.
+const rruleMaxYears = 400
+
+// rruleMaxCount bounds the COUNT of a rule, since the occurrences of such rules
+// are enumerated from their start.
+const rruleMaxCount = 100000
+
+// rruleMaxIterations bounds the number of periods visited while searching for
+// occurrences, including the periods skipped at once. Rules which don't fire
+// within it are considered to never fire.
+const rruleMaxIterations = 200000
+
+type rruleDay struct {
+	weekday time.Weekday
+
+	// ordinal selects the nth weekday of the month e.g. 1 for the first and -1
+	// for the last. It's 0 for every weekday of the month.
+	ordinal int
+}
+
+// RecurrenceRule is a parsed RFC 5545 recurrence rule, optionally with its
+// start time e.g. "DTSTART:20240101T090000Z\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE".
+type RecurrenceRule struct {
+	rule       string
+	start      time.Time
+	frequency  string
+	interval   int
+	count      int
+	until      time.Time
+	byMonth    []int
+	byMonthDay []int
+	byDay      []rruleDay
+	byHour     []int
+	byMinute   []int
+	bySecond   []int
+	weekStart  time.Weekday
+
+	// counted caches the occurrences of rules with a COUNT and a DTSTART,
+	// which are all enumerated from their start by the first call to Next.
+	counted *rruleOccurrences
+}
+
+type rruleOccurrences struct {
+	once        sync.Once
+	occurrences []time.Time
+}
+
+// ParseRRule parses an RFC 5545 recurrence rule such as
+// "FREQ=MONTHLY;BYDAY=-1FR;COUNT=12", with or without the "RRULE:" prefix. It
+// may be preceded by a DTSTART line, which may have a TZID parameter. The
+// FREQ (MINUTELY to YEARLY), INTERVAL, COUNT, UNTIL, BYMONTH, BYMONTHDAY,
+// BYDAY, BYHOUR, BYMINUTE, BYSECOND and WKST parts are supported. Weekdays of
+// BYDAY may only have an ordinal, e.g. 2MO, in MONTHLY rules and YEARLY rules
+// with BYMONTH, where it selects the weekday within the month. Rules whose
+// BYMONTH and BYMONTHDAY parts never match a day, e.g. February 30, are
+// rejected.
+func ParseRRule(input string) (RecurrenceRule, error) {
+	r := RecurrenceRule{interval: 1, weekStart: time.Monday}
+
+	var rule string
+	for _, line := range strings.Split(strings.TrimSpace(input), "\n") {
+		line = strings.TrimSpace(line)
+		name, value, _ := strings.Cut(line, ":")
+
+		switch {
+		case strings.HasPrefix(strings.ToUpper(line), "DTSTART"):
+			start, err := parseRRuleStart(name, value)
+			if err != nil {
+				return RecurrenceRule{}, err
+			}
+			r.start = start
+		case strings.EqualFold(name, "RRULE") && rule == "":
+			rule = value
+		case strings.HasPrefix(strings.ToUpper(line), "FREQ=") && rule == "":
+			rule = line
+		default:
+			return RecurrenceRule{}, fmt.Errorf("unexpected line '%s' in recurrence rule", line)
+		}
+	}
+
+	if rule == "" {
+		return RecurrenceRule{}, fmt.Errorf("missing RRULE in '%s'", input)
+	}
+	r.rule = strings.TrimSpace(input)
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(key)
+		if !ok || value == "" || seen[key] {
+			return RecurrenceRule{}, fmt.Errorf("invalid part '%s' of recurrence rule", part)
+		}
+		seen[key] = true
+
+		if err := r.parsePart(key, strings.ToUpper(value)); err != nil {
+			return RecurrenceRule{}, err
+		}
+	}
+
+	switch {
+	case r.frequency == "":
+		return RecurrenceRule{}, fmt.Errorf("missing FREQ in recurrence rule '%s'", rule)
+	case r.count != 0 && !r.until.IsZero():
+		return RecurrenceRule{}, fmt.Errorf("recurrence rule '%s' cannot have both COUNT and UNTIL", rule)
+	}
+
+	for _, day := range r.byDay {
+		monthly := r.frequency == RRULE_MONTHLY || (r.frequency == RRULE_YEARLY && len(r.byMonth) != 0)
+		if day.ordinal != 0 && !monthly {
+			return RecurrenceRule{}, fmt.Errorf("BYDAY ordinals are only supported in MONTHLY rules, and YEARLY rules with BYMONTH")
+		}
+	}
+
+	if !r.hasPossibleDay() {
+		return RecurrenceRule{}, fmt.Errorf("recurrence rule '%s' never fires", rule)
+	}
+
+	if r.count != 0 && !r.start.IsZero() {
+		r.counted = &rruleOccurrences{}
+	}
+	return r, nil
+}
+
+func parseRRuleStart(name string, value string) (time.Time, error) {
+	location := time.UTC
+	for _, param := range strings.Split(name, ";")[1:] {
+		key, paramValue, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "TZID") {
+			var err error
+			if location, err = time.LoadLocation(paramValue); err != nil {
+				return time.Time{}, fmt.Errorf("unknown time zone '%s' in DTSTART", paramValue)
+			}
+		}
+	}
+
+	start, err := parseRRuleTime(value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid DTSTART '%s'", value)
+	}
+	return start, nil
+}
+
+// parseRRuleTime parses an RFC 5545 date or date-time, e.g. 20240101 or
+// 20240101T090000Z. Times without the Z suffix are in the provided location.
+func parseRRuleTime(value string, location *time.Location) (time.Time, error) {
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse("20060102T150405Z", value)
+	case strings.Contains(value, "T"):
+		return time.ParseInLocation("20060102T150405", value, location)
+	}
+	return time.ParseInLocation("20060102", value, location)
+}
+
+func (r *RecurrenceRule) parsePart(key string, value string) error {
+	var err error
+	switch key {
+	case "FREQ":
+		frequencies := []string{RRULE_YEARLY, RRULE_MONTHLY, RRULE_WEEKLY, RRULE_DAILY, RRULE_HOURLY, RRULE_MINUTELY}
+		if !slices.Contains(frequencies, value) {
+			return fmt.Errorf("unsupported FREQ '%s'", value)
+		}
+		r.frequency = value
+	case "INTERVAL":
+		if r.interval, err = strconv.Atoi(value); err != nil || r.interval < 1 {
+			return fmt.Errorf("invalid INTERVAL '%s'", value)
+		}
+	case "COUNT":
+		if r.count, err = strconv.Atoi(value); err != nil || r.count < 1 || r.count > rruleMaxCount {
+			return fmt.Errorf("invalid COUNT '%s'", value)
+		}
+	case "UNTIL":
+		if r.until, err = parseRRuleTime(value, time.UTC); err != nil {
+			return fmt.Errorf("invalid UNTIL '%s'", value)
+		}
+	case "BYMONTH":
+		r.byMonth, err = parseRRuleList(key, value, 1, 12, false)
+	case "BYMONTHDAY":
+		r.byMonthDay, err = parseRRuleList(key, value, 1, 31, true)
+	case "BYHOUR":
+		r.byHour, err = parseRRuleList(key, value, 0, 23, false)
+	case "BYMINUTE":
+		r.byMinute, err = parseRRuleList(key, value, 0, 59, false)
+	case "BYSECOND":
+		r.bySecond, err = parseRRuleList(key, value, 0, 59, false)
+	case "BYDAY":
+		for _, day := range strings.Split(value, ",") {
+			parsed, errDay := parseRRuleDay(day)
+			if errDay != nil {
+				return errDay
+			}
+			r.byDay = append(r.byDay, parsed)
+		}
+	case "WKST":
+		day, errDay := parseRRuleDay(value)
+		if errDay != nil || day.ordinal != 0 {
+			return fmt.Errorf("invalid WKST '%s'", value)
+		}
+		r.weekStart = day.weekday
+	default:
+		return fmt.Errorf("unsupported recurrence rule part '%s'", key)
+	}
+	return err
+}
+
+// parseRRuleList parses a list of numbers within the provided bounds, and
+// their negation when negative numbers are allowed e.g. -1 for the last day of
+// the month. The returned list is sorted.
+func parseRRuleList(key string, value string, minimum int, maximum int, negative bool) ([]int, error) {
+	var list []int
+	for _, text := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(text)
+		valid := number >= minimum && number <= maximum
+		if negative && number < 0 {
+			valid = -number >= minimum && -number <= maximum
+		}
+
+		if err != nil || !valid {
+			return nil, fmt.Errorf("invalid %s value '%s'", key, text)
+		}
+		list = append(list, number)
+	}
+
+	slices.Sort(list)
+	return slices.Compact(list), nil
+}
+
+func parseRRuleDay(text string) (rruleDay, error) {
+	if len(text) < 2 {
+		return rruleDay{}, fmt.Errorf("invalid BYDAY value '%s'", text)
+	}
+
+	weekday := slices.Index(rruleDayNames, text[len(text)-2:])
+	if weekday == -1 {
+		return rruleDay{}, fmt.Errorf("invalid BYDAY value '%s'", text)
+	}
+
+	ordinal := 0
+	if prefix := text[:len(text)-2]; prefix != "" {
+		var err error
+		if ordinal, err = strconv.Atoi(prefix); err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return rruleDay{}, fmt.Errorf("invalid BYDAY value '%s'", text)
+		}
+	}
+	return rruleDay{weekday: time.Weekday(weekday), ordinal: ordinal}, nil
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// hasPossibleDay reports whether a day of one of the months of the rule
+// matches its days of the month, in leap years. The day of the month of YEARLY
+// and MONTHLY rules defaults to the one of the start time.
+func (r RecurrenceRule) hasPossibleDay() bool {
+	monthDays := r.byMonthDay
+	if len(monthDays) == 0 {
+		monthly := r.frequency == RRULE_YEARLY || r.frequency == RRULE_MONTHLY
+		if !monthly || len(r.byDay) != 0 || r.start.IsZero() {
+			return true
+		}
+		monthDays = []int{r.start.Day()}
+	}
+
+	months := r.byMonth
+	if len(months) == 0 {
+		months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	}
+
+	for _, month := range months {
+		days := daysIn(2024, time.Month(month))
+		for _, day := range monthDays {
+			if day <= days && -day <= days {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reachesTimeOfDay reports whether the periods of an HOURLY or MINUTELY rule
+// reach one of its hours and minutes. Periods move by the interval, so only
+// the times of day distant from the one of the start time by a multiple of the
+// greatest common divisor of the interval and the day are reached.
+func (r RecurrenceRule) reachesTimeOfDay(start time.Time) bool {
+	var day, offset int
+	var times []int
+	switch {
+	case r.frequency == RRULE_HOURLY && len(r.byHour) != 0:
+		day, offset, times = 24, start.Hour(), r.byHour
+	case r.frequency == RRULE_MINUTELY && (len(r.byHour) != 0 || len(r.byMinute) != 0):
+		day, offset = 24*60, start.Hour()*60+start.Minute()
+		for hour := 0; hour < 24; hour++ {
+			for minute := 0; minute < 60; minute++ {
+				matchesHour := len(r.byHour) == 0 || slices.Contains(r.byHour, hour)
+				if matchesHour && (len(r.byMinute) == 0 || slices.Contains(r.byMinute, minute)) {
+					times = append(times, hour*60+minute)
+				}
+			}
+		}
+	default:
+		return true
+	}
+
+	step, remainder := r.interval, day
+	for remainder != 0 {
+		step, remainder = remainder, step%remainder
+	}
+
+	return slices.ContainsFunc(times, func(value int) bool {
+		return (value-offset)%step == 0
+	})
+}
+
+// periodSeconds returns the length of the periods of an HOURLY or MINUTELY
+// rule, in seconds.
+func (r RecurrenceRule) periodSeconds() int64 {
+	if r.frequency == RRULE_HOURLY {
+		return int64(r.interval) * 60 * 60
+	}
+	return int64(r.interval) * 60
+}
+
+// periodUnit returns the unit of the periods of an HOURLY or MINUTELY rule.
+func (r RecurrenceRule) periodUnit() time.Duration {
+	if r.frequency == RRULE_HOURLY {
+		return time.Hour
+	}
+	return time.Minute
+}
+
+// periodStart returns the start of the period with the provided index, e.g.
+// the first day of the month of a MONTHLY rule.
+func (r RecurrenceRule) periodStart(start time.Time, index int) time.Time {
+	location := start.Location()
+	step := index * r.interval
+
+	switch r.frequency {
+	case RRULE_YEARLY:
+		return time.Date(start.Year()+step, 1, 1, 0, 0, 0, 0, location)
+	case RRULE_MONTHLY:
+		return time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, location)
+	case RRULE_WEEKLY:
+		offset := (int(start.Weekday()) - int(r.weekStart) + 7) % 7
+		return time.Date(start.Year(), start.Month(), start.Day()-offset+7*step, 0, 0, 0, 0, location)
+	case RRULE_DAILY:
+		return time.Date(start.Year(), start.Month(), start.Day()+step, 0, 0, 0, 0, location)
+	}
+
+	// periods are counted in seconds, since a time.Duration can't span more
+	// than 292 years.
+	first := start.Truncate(r.periodUnit()).Unix()
+	return time.Unix(first+int64(index)*r.periodSeconds(), 0).In(location)
+}
+
+// periodIndex returns the index of the first period of an HOURLY or MINUTELY
+// rule starting at or after the provided time.
+func (r RecurrenceRule) periodIndex(start time.Time, at time.Time) int {
+	seconds, length := at.Unix()-start.Truncate(r.periodUnit()).Unix(), r.periodSeconds()
+	return int((seconds + length - 1) / length)
+}
+
+// skipTo returns the start of the next day, hour or minute which may match,
+// when the provided period of an HOURLY or MINUTELY rule isn't within a
+// matching day, hour or minute, so that the periods in between are skipped.
+func (r RecurrenceRule) skipTo(period time.Time, start time.Time) (time.Time, bool) {
+	if r.frequency != RRULE_HOURLY && r.frequency != RRULE_MINUTELY {
+		return time.Time{}, false
+	}
+
+	year, month, day := period.Date()
+	if !r.matchesDay(time.Date(year, month, day, 0, 0, 0, 0, period.Location()), start) {
+		return time.Date(year, month, day+1, 0, 0, 0, 0, period.Location()), true
+	}
+
+	hour, minute := period.Hour(), period.Minute()
+	if len(r.byHour) != 0 && !slices.Contains(r.byHour, hour) {
+		// BYHOUR is sorted, the next listed hour being today or tomorrow.
+		if i, _ := slices.BinarySearch(r.byHour, hour); i < len(r.byHour) {
+			return time.Date(year, month, day, r.byHour[i], 0, 0, 0, period.Location()), true
+		}
+		return time.Date(year, month, day+1, 0, 0, 0, 0, period.Location()), true
+	}
+
+	if r.frequency == RRULE_MINUTELY && len(r.byMinute) != 0 && !slices.Contains(r.byMinute, minute) {
+		if i, _ := slices.BinarySearch(r.byMinute, minute); i < len(r.byMinute) {
+			return time.Date(year, month, day, hour, r.byMinute[i], 0, 0, period.Location()), true
+		}
+		return time.Date(year, month, day, hour+1, 0, 0, 0, period.Location()), true
+	}
+	return time.Time{}, false
+}
+
+// firstPeriod returns the index of a period which starts before the provided
+// time, skipping the periods which can't have occurrences after it. The
+// periods of rules with a COUNT are all needed to count the occurrences.
+func (r RecurrenceRule) firstPeriod(start time.Time, after time.Time) int {
+	if r.count != 0 || !after.After(start) {
+		return 0
+	}
+
+	// elapsed is in seconds, since a time.Duration can't span more than 292
+	// years.
+	var periods int
+	elapsed := after.Unix() - start.Unix()
+	switch r.frequency {
+	case RRULE_YEARLY:
+		periods = after.Year() - start.Year()
+	case RRULE_MONTHLY:
+		periods = (after.Year()-start.Year())*12 + int(after.Month()) - int(start.Month())
+	case RRULE_WEEKLY:
+		periods = int(elapsed / (7 * 24 * 60 * 60))
+	case RRULE_DAILY:
+		periods = int(elapsed / (24 * 60 * 60))
+	case RRULE_HOURLY:
+		periods = int(elapsed / (60 * 60))
+	default:
+		periods = int(elapsed / 60)
+	}
+	return max(periods/r.interval-1, 0)
+}
+
+func (r RecurrenceRule) matchesDay(day time.Time, start time.Time) bool {
+	switch {
+	case len(r.byMonth) != 0:
+		if !slices.Contains(r.byMonth, int(day.Month())) {
+			return false
+		}
+	case r.frequency == RRULE_YEARLY && len(r.byMonthDay) == 0 && len(r.byDay) == 0:
+		if day.Month() != start.Month() {
+			return false
+		}
+	}
+
+	days := daysIn(day.Year(), day.Month())
+	switch {
+	case len(r.byMonthDay) != 0:
+		if !slices.Contains(r.byMonthDay, day.Day()) && !slices.Contains(r.byMonthDay, day.Day()-days-1) {
+			return false
+		}
+	case (r.frequency == RRULE_YEARLY || r.frequency == RRULE_MONTHLY) && len(r.byDay) == 0:
+		if day.Day() != start.Day() {
+			return false
+		}
+	}
+
+	switch {
+	case len(r.byDay) != 0:
+		nth, nthFromEnd := (day.Day()-1)/7+1, -((days-day.Day())/7 + 1)
+		return slices.ContainsFunc(r.byDay, func(byDay rruleDay) bool {
+			return byDay.weekday == day.Weekday() && (byDay.ordinal == 0 || byDay.ordinal == nth || byDay.ordinal == nthFromEnd)
+		})
+	case r.frequency == RRULE_WEEKLY && len(r.byMonthDay) == 0:
+		return day.Weekday() == start.Weekday()
+	}
+	return true
+}
+
+// timesOfDay returns the hours, minutes or seconds of the occurrences within
+// a period. The unit of the frequency itself, e.g. the hour of an HOURLY rule,
+// is the one of the period, while the units below it default to the one of
+// the start time.
+func timesOfDay(list []int, periodValue int, startValue int, isPeriodUnit bool, isAbovePeriod bool) []int {
+	switch {
+	case isPeriodUnit || isAbovePeriod:
+		if len(list) != 0 && !slices.Contains(list, periodValue) {
+			return nil
+		}
+		return []int{periodValue}
+	case len(list) != 0:
+		return list
+	}
+	return []int{startValue}
+}
+
+// occurrences returns the occurrences within the provided period, in order.
+func (r RecurrenceRule) occurrences(period time.Time, start time.Time) []time.Time {
+	var days int
+	switch r.frequency {
+	case RRULE_YEARLY:
+		days = time.Date(period.Year(), 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	case RRULE_MONTHLY:
+		days = daysIn(period.Year(), period.Month())
+	case RRULE_WEEKLY:
+		days = 7
+	default:
+		days = 1
+	}
+
+	hourly := r.frequency == RRULE_HOURLY
+	minutely := r.frequency == RRULE_MINUTELY
+	hours := timesOfDay(r.byHour, period.Hour(), start.Hour(), hourly, minutely)
+	minutes := timesOfDay(r.byMinute, period.Minute(), start.Minute(), minutely, false)
+	seconds := timesOfDay(r.bySecond, 0, start.Second(), false, false)
+
+	var occurrences []time.Time
+	for i := 0; i < days; i++ {
+		day := time.Date(period.Year(), period.Month(), period.Day()+i, 0, 0, 0, 0, period.Location())
+		if !r.matchesDay(day, start) {
+			continue
+		}
+
+		for _, hour := range hours {
+			for _, minute := range minutes {
+				for _, second := range seconds {
+					occurrences = append(occurrences, time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, day.Location()))
+				}
+			}
+		}
+	}
+	return occurrences
+}
+
+// Next returns the first occurrence of the rule after the provided time, or
+// the zero time when there is none within 400 years. Rules without a DTSTART
+// start at the provided time.
+func (r RecurrenceRule) Next(after time.Time) time.Time {
+	if r.counted != nil {
+		r.counted.once.Do(func() {
+			r.walk(r.start, r.start, func(occurrence time.Time) bool {
+				r.counted.occurrences = append(r.counted.occurrences, occurrence)
+				return true
+			})
+		})
+
+		occurrences := r.counted.occurrences
+		i := sort.Search(len(occurrences), func(i int) bool {
+			return occurrences[i].After(after)
+		})
+
+		if i == len(occurrences) {
+			return time.Time{}
+		}
+		return occurrences[i]
+	}
+
+	start := r.start
+	if start.IsZero() {
+		start = after.Truncate(time.Second)
+	}
+
+	var next time.Time
+	r.walk(start, after, func(occurrence time.Time) bool {
+		if occurrence.After(after) {
+			next = occurrence
+			return false
+		}
+		return true
+	})
+	return next
+}
+
+// walk calls visit with the occurrences of the rule from the provided start,
+// in order, skipping the periods which can't have occurrences after the
+// provided time, until visit returns false or the rule ends. The search is
+// bounded by rruleMaxYears and rruleMaxIterations.
+func (r RecurrenceRule) walk(start time.Time, after time.Time, visit func(time.Time) bool) {
+	if !r.reachesTimeOfDay(start) {
+		return
+	}
+
+	deadline := after
+	if start.After(deadline) {
+		deadline = start
+	}
+	deadline = deadline.AddDate(rruleMaxYears, 0, 0)
+
+	counted := 0
+	index := r.firstPeriod(start, after)
+	for iteration := 0; iteration < rruleMaxIterations; iteration, index = iteration+1, index+1 {
+		period := r.periodStart(start, index)
+		if period.After(deadline) {
+			return
+		}
+
+		if next, ok := r.skipTo(period, start); ok {
+			index = r.periodIndex(start, next) - 1
+			continue
+		}
+
+		for _, occurrence := range r.occurrences(period, start) {
+			if occurrence.Before(start) {
+				continue
+			}
+
+			if !r.until.IsZero() && occurrence.After(r.until) {
+				return
+			}
+
+			counted++
+			if r.count != 0 && counted > r.count {
+				return
+			}
+
+			if !visit(occurrence) {
+				return
+			}
+		}
+	}
+}
+
+// Frequency returns the FREQ of the rule e.g. RRULE_WEEKLY.
+func (r RecurrenceRule) Frequency() string {
+	return r.frequency
+}
+
+func (r RecurrenceRule) String() string {
+	return r.rule
+}
@@ -0,0 +1,195 @@
+package vld
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	valid := []string{
+		"FREQ=DAILY",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;INTERVAL=2",
+		"FREQ=MONTHLY;BYDAY=-1FR;COUNT=12",
+		"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29",
+		"DTSTART;TZID=Europe/Berlin:20240101T090000\nRRULE:FREQ=DAILY;UNTIL=20240201T000000Z",
+	}
+
+	for _, input := range valid {
+		if _, err := ParseRRule(input); err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+	}
+
+	invalid := []string{
+		"",
+		"FREQ=SECONDLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20240101",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"DTSTART:2024\nRRULE:FREQ=DAILY",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+		"FREQ=MONTHLY;BYMONTH=4,6;BYMONTHDAY=-31",
+		"DTSTART:20240131T090000Z\nRRULE:FREQ=YEARLY;BYMONTH=2",
+		"FREQ=DAILY;COUNT=100001",
+	}
+
+	for _, input := range invalid {
+		if _, err := ParseRRule(input); err == nil {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+func TestRRuleNext(t *testing.T) {
+	testCases := []struct {
+		rule     string
+		after    time.Time
+		expected []time.Time
+	}{
+		{
+			rule:  "DTSTART:20240101T090000Z\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE",
+			after: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule:  "DTSTART:20240105T180000Z\nRRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			after: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 26, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 23, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 29, 18, 0, 0, 0, time.UTC),
+				{},
+			},
+		},
+		{
+			rule:  "DTSTART:20240131T080000Z\nRRULE:FREQ=MONTHLY",
+			after: time.Date(2024, 1, 31, 8, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 3, 31, 8, 0, 0, 0, time.UTC),
+				time.Date(2024, 5, 31, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule:  "DTSTART:20240101T000000Z\nRRULE:FREQ=HOURLY;INTERVAL=6;BYMINUTE=0,30",
+			after: time.Date(2024, 6, 1, 5, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 6, 1, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 6, 1, 6, 30, 0, 0, time.UTC),
+				time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule:  "DTSTART:20200229T100000Z\nRRULE:FREQ=YEARLY",
+			after: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule:  "DTSTART:17000101T000000Z\nRRULE:FREQ=MINUTELY;BYMONTH=2;BYMONTHDAY=29;BYHOUR=12",
+			after: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC),
+				time.Date(2028, 2, 29, 12, 1, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule:  "DTSTART:20240101T000000Z\nRRULE:FREQ=HOURLY;BYHOUR=9,17;BYMINUTE=15",
+			after: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 6, 1, 17, 15, 0, 0, time.UTC),
+				time.Date(2024, 6, 2, 9, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule:  "DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY;UNTIL=20240102T090000Z",
+			after: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC),
+				{},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		rule, err := ParseRRule(testCase.rule)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		after := testCase.after
+		for _, expected := range testCase.expected {
+			next := rule.Next(after)
+			if !next.Equal(expected) {
+				t.Errorf("unexpected next time %s after %s for %q", next, after, testCase.rule)
+				return
+			}
+			after = next
+		}
+	}
+}
+
+func TestRRuleNextWithoutStart(t *testing.T) {
+	rule, _ := ParseRRule("FREQ=DAILY;INTERVAL=2")
+	after := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	if next := rule.Next(after); !next.Equal(after.AddDate(0, 0, 2)) {
+		t.Errorf("unexpected next time %s", next)
+		return
+	}
+}
+
+func TestRRuleNextNever(t *testing.T) {
+	never := []string{
+		// every other hour from midnight is an even hour.
+		"DTSTART:20240101T000000Z\nRRULE:FREQ=HOURLY;INTERVAL=2;BYHOUR=1",
+		// every 7 days from a Monday is a Monday.
+		"DTSTART:20240101T000000Z\nRRULE:FREQ=DAILY;INTERVAL=7;BYDAY=TU",
+		"DTSTART:20240101T000000Z\nRRULE:FREQ=MINUTELY;INTERVAL=10080;BYDAY=TU",
+	}
+
+	for _, input := range never {
+		rule, err := ParseRRule(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if next := rule.Next(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)); !next.IsZero() {
+			t.Errorf("unexpected next time %s for %q", next, input)
+			return
+		}
+	}
+}
+
+func TestRRuleNextBounded(t *testing.T) {
+	rule, err := RRule("DTSTART:20000101T000000Z\nRRULE:FREQ=MINUTELY;BYMINUTE=0;COUNT=100000")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	// the occurrences of rules with a COUNT are enumerated once, and the
+	// minutes which can't match are skipped.
+	started := time.Now()
+	clock := fixedClock(time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC))
+	if _, err := MaxInterval(2*time.Hour, 50, clock)(rule); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("searching for the next occurrences took %s", elapsed)
+		return
+	}
+}
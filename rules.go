@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/url"
 	"regexp"
 	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // NonEmptyString check if provided input is a non-empty string.
@@ -26,8 +28,9 @@ func NonEmptyString(input any) (any, error) {
 	return asString, nil
 }
 
-// Length check if the provided input is a string and its length is equal to
-// the provided length.
+// Length check if the provided input is a string and its length, in characters
+// (runes), is equal to the provided length. See LengthIn to count bytes or
+// grapheme clusters instead.
 func Length(length int) Rule {
 	return func(input any) (any, error) {
 		issue := &Issue{
@@ -37,7 +40,7 @@ func Length(length int) Rule {
 		}
 
 		asString, ok := input.(string)
-		if !ok || len(asString) != length {
+		if !ok || utf8.RuneCountInString(asString) != length {
 			return nil, issue
 		}
 
@@ -47,17 +50,30 @@ func Length(length int) Rule {
 
 // Min if the provided number is an int / float(64), check input is greater than
 // or equal to the target. If the provided input is a string, check its length
-// is more than or equal to the target.
+// in characters (runes) is more than or equal to the target. If the target is a time.Time, check the
+// input is a time.Time on or after the target.
 func Min(target any) Rule {
+	errConfig := comparisonTargetError("Min", target)
+
 	return func(input any) (any, error) {
-		targetAsInt, okTargetIntCast := target.(int)
-		targetAsFloat, okTargetFloatCast := target.(float64)
+		if errConfig != nil {
+			return nil, errConfig
+		}
 
-		if !okTargetIntCast && !okTargetFloatCast {
-			return nil, errors.New("invalid target type provided")
+		if targetAsTime, ok := target.(time.Time); ok {
+			return compareTime(input, Issue{
+				Code:    CODE_MIN,
+				Message: "The date must be on or after " + targetAsTime.Format(time.RFC3339),
+				Value:   targetAsTime.Format(time.RFC3339),
+			}, func(t time.Time) bool {
+				return !t.Before(targetAsTime)
+			})
 		}
 
-		switch t := input.(type) {
+		targetAsInt, okTargetIntCast := target.(int)
+		targetAsFloat, okTargetFloatCast := target.(float64)
+
+		switch t := normalizeNumber(input).(type) {
 		case int:
 			{
 				if okTargetIntCast {
@@ -68,7 +84,7 @@ func Min(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
@@ -79,7 +95,7 @@ func Min(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 			}
 
@@ -93,7 +109,7 @@ func Min(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
@@ -104,30 +120,34 @@ func Min(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 			}
 
 		case string:
 			{
 				if okTargetIntCast {
-					if len(t) < targetAsInt {
+					if utf8.RuneCountInString(t) < targetAsInt {
 						return nil, Issue{
 							Code:    CODE_MIN,
 							Message: fmt.Sprintf("The length must be more than %d characters", target),
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
-					return nil, errors.New("string length cannot be a floating point number")
+					return nil, ConfigError{Rule: "Min", Message: "string length cannot be a floating point number"}
 				}
 			}
 
 		default:
-			return nil, errors.New("invalid data type provided")
+			return nil, Issue{
+				Code:    CODE_MIN,
+				Message: "Please provide a number",
+				Value:   target,
+			}
 		}
 
 		return nil, nil
@@ -136,17 +156,30 @@ func Min(target any) Rule {
 
 // Max if the provided number is an int / float(64), check input is less than
 // or equal to the target. If the provided input is a string, check its length
-// is less than or equal to the target.
+// in characters (runes) is less than or equal to the target. If the target is a time.Time, check the
+// input is a time.Time on or before the target.
 func Max(target any) Rule {
+	errConfig := comparisonTargetError("Max", target)
+
 	return func(input any) (any, error) {
-		targetAsInt, okTargetIntCast := target.(int)
-		targetAsFloat, okTargetFloatCast := target.(float64)
+		if errConfig != nil {
+			return nil, errConfig
+		}
 
-		if !okTargetIntCast && !okTargetFloatCast {
-			return nil, errors.New("invalid target type provided")
+		if targetAsTime, ok := target.(time.Time); ok {
+			return compareTime(input, Issue{
+				Code:    CODE_MAX,
+				Message: "The date must be on or before " + targetAsTime.Format(time.RFC3339),
+				Value:   targetAsTime.Format(time.RFC3339),
+			}, func(t time.Time) bool {
+				return !t.After(targetAsTime)
+			})
 		}
 
-		switch t := input.(type) {
+		targetAsInt, okTargetIntCast := target.(int)
+		targetAsFloat, okTargetFloatCast := target.(float64)
+
+		switch t := normalizeNumber(input).(type) {
 		case int:
 			{
 				if okTargetIntCast {
@@ -157,7 +190,7 @@ func Max(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
@@ -168,7 +201,7 @@ func Max(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 			}
 
@@ -182,7 +215,7 @@ func Max(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
@@ -193,30 +226,34 @@ func Max(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 			}
 
 		case string:
 			{
 				if okTargetIntCast {
-					if len(t) > targetAsInt {
+					if utf8.RuneCountInString(t) > targetAsInt {
 						return nil, Issue{
 							Code:    CODE_MAX,
 							Message: fmt.Sprintf("The length must be less than %d characters", target),
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
-					return nil, errors.New("string length cannot be a floating point number")
+					return nil, ConfigError{Rule: "Max", Message: "string length cannot be a floating point number"}
 				}
 			}
 
 		default:
-			return nil, errors.New("invalid data type provided")
+			return nil, Issue{
+				Code:    CODE_MAX,
+				Message: "Please provide a number",
+				Value:   target,
+			}
 		}
 
 		return nil, nil
@@ -225,31 +262,52 @@ func Max(target any) Rule {
 
 // GreaterThan if the provided number is an int / float(64), check input is more
 // than (but not equal) to the target. If the provided input is a string, check its
-// length is more than (but not equal) to the target.
+// length in characters (runes) is more than (but not equal) to the target. If the target is a
+// time.Time, check the input is a time.Time after the target.
 func GreaterThan(target any) Rule {
+	errConfig := comparisonTargetError("GreaterThan", target)
+
 	return func(input any) (any, error) {
-		targetAsInt, okTargetIntCast := target.(int)
-		targetAsFloat, okTargetFloatCast := target.(float64)
+		if errConfig != nil {
+			return nil, errConfig
+		}
 
-		if !okTargetIntCast && !okTargetFloatCast {
-			return nil, errors.New("invalid target type provided")
+		if targetAsTime, ok := target.(time.Time); ok {
+			return compareTime(input, Issue{
+				Code:    CODE_GREATER_THAN,
+				Message: "The date must be after " + targetAsTime.Format(time.RFC3339),
+				Value:   targetAsTime.Format(time.RFC3339),
+			}, func(t time.Time) bool {
+				return t.After(targetAsTime)
+			})
 		}
 
-		switch t := input.(type) {
+		targetAsInt, okTargetIntCast := target.(int)
+		targetAsFloat, okTargetFloatCast := target.(float64)
+
+		switch t := normalizeNumber(input).(type) {
 		case int:
 			{
 				if okTargetIntCast {
 					if t <= targetAsInt {
-						return nil, fmt.Errorf("number must be greater than %d", target)
+						return nil, Issue{
+							Code:    CODE_GREATER_THAN,
+							Message: fmt.Sprintf("The number must be greater than %d", target),
+							Value:   target,
+						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
 					if float64(t) <= targetAsFloat {
-						return nil, fmt.Errorf("number must be greater than %d", target)
+						return nil, Issue{
+							Code:    CODE_GREATER_THAN,
+							Message: fmt.Sprintf("The number must be greater than %d", target),
+							Value:   target,
+						}
 					}
-					return t, nil
+					return input, nil
 				}
 			}
 
@@ -257,35 +315,51 @@ func GreaterThan(target any) Rule {
 			{
 				if okTargetIntCast {
 					if t <= float64(targetAsInt) {
-						return nil, fmt.Errorf("number must be greater than %d", target)
+						return nil, Issue{
+							Code:    CODE_GREATER_THAN,
+							Message: fmt.Sprintf("The number must be greater than %d", target),
+							Value:   target,
+						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
 					if t <= targetAsFloat {
-						return nil, fmt.Errorf("number must be greater than %d", target)
+						return nil, Issue{
+							Code:    CODE_GREATER_THAN,
+							Message: fmt.Sprintf("The number must be greater than %d", target),
+							Value:   target,
+						}
 					}
-					return t, nil
+					return input, nil
 				}
 			}
 
 		case string:
 			{
 				if okTargetIntCast {
-					if len(t) <= targetAsInt {
-						return nil, fmt.Errorf("length must be more than %d characters", target)
+					if utf8.RuneCountInString(t) <= targetAsInt {
+						return nil, Issue{
+							Code:    CODE_GREATER_THAN,
+							Message: fmt.Sprintf("The length must be more than %d characters", target),
+							Value:   target,
+						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
-					return nil, errors.New("string length cannot be a floating point number")
+					return nil, ConfigError{Rule: "GreaterThan", Message: "string length cannot be a floating point number"}
 				}
 			}
 
 		default:
-			return nil, errors.New("invalid data type provided")
+			return nil, Issue{
+				Code:    CODE_GREATER_THAN,
+				Message: "Please provide a number",
+				Value:   target,
+			}
 		}
 
 		return nil, nil
@@ -294,17 +368,30 @@ func GreaterThan(target any) Rule {
 
 // LessThan if the provided number is an int / float(64), check input is less
 // than (but not equal) to the target. If the provided input is a string, check its
-// length is less than (but not equal) to the target.
+// length in characters (runes) is less than (but not equal) to the target. If the target is a
+// time.Time, check the input is a time.Time before the target.
 func LessThan(target any) Rule {
+	errConfig := comparisonTargetError("LessThan", target)
+
 	return func(input any) (any, error) {
-		targetAsInt, okTargetIntCast := target.(int)
-		targetAsFloat, okTargetFloatCast := target.(float64)
+		if errConfig != nil {
+			return nil, errConfig
+		}
 
-		if !okTargetIntCast && !okTargetFloatCast {
-			return nil, errors.New("invalid target type provided")
+		if targetAsTime, ok := target.(time.Time); ok {
+			return compareTime(input, Issue{
+				Code:    CODE_LESS_THAN,
+				Message: "The date must be before " + targetAsTime.Format(time.RFC3339),
+				Value:   targetAsTime.Format(time.RFC3339),
+			}, func(t time.Time) bool {
+				return t.Before(targetAsTime)
+			})
 		}
 
-		switch t := input.(type) {
+		targetAsInt, okTargetIntCast := target.(int)
+		targetAsFloat, okTargetFloatCast := target.(float64)
+
+		switch t := normalizeNumber(input).(type) {
 		case int:
 			{
 				if okTargetIntCast {
@@ -315,7 +402,7 @@ func LessThan(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
@@ -326,7 +413,7 @@ func LessThan(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 			}
 
@@ -340,7 +427,7 @@ func LessThan(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
@@ -351,30 +438,34 @@ func LessThan(target any) Rule {
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 			}
 
 		case string:
 			{
 				if okTargetIntCast {
-					if len(t) >= targetAsInt {
+					if utf8.RuneCountInString(t) >= targetAsInt {
 						return nil, Issue{
 							Code:    CODE_LESS_THAN,
 							Message: fmt.Sprintf("The length must be less than %d characters", target),
 							Value:   target,
 						}
 					}
-					return t, nil
+					return input, nil
 				}
 
 				if okTargetFloatCast {
-					return nil, errors.New("string length cannot be a floating point number")
+					return nil, ConfigError{Rule: "LessThan", Message: "string length cannot be a floating point number"}
 				}
 			}
 
 		default:
-			return nil, errors.New("invalid data type provided")
+			return nil, Issue{
+				Code:    CODE_LESS_THAN,
+				Message: "Please provide a number",
+				Value:   target,
+			}
 		}
 
 		return nil, nil
@@ -472,8 +563,8 @@ func NotHasSuffix(suffix string) Rule {
 	}
 }
 
-// Equals check if the provided input is the same as the required input.
-// TODO: merge DateEquals into this
+// Equals check if the provided input is the same as the required input. Times
+// are equal when they represent the same instant, even in different locations.
 // TODO: extend to allow comparison of numbers
 func Equals(targetName string, targetValue any) Rule {
 	return func(input any) (any, error) {
@@ -482,6 +573,11 @@ func Equals(targetName string, targetValue any) Rule {
 			Message: fmt.Sprintf("The input must be the same as '%s'", targetName),
 			Value:   targetName, // TODO: confirm targetName or targetValue
 		}
+
+		if targetAsTime, ok := targetValue.(time.Time); ok {
+			return compareTime(input, issue, targetAsTime.Equal)
+		}
+
 		if targetValue != input {
 			return nil, issue
 		}
@@ -527,7 +623,13 @@ func URL(input any) (any, error) {
 // Regexp check if the provided input is a valid string and matches the required
 // regular expression.
 func Regexp(pattern string) Rule {
+	compiled, errCompile := regexp.Compile(pattern)
+
 	return func(input any) (any, error) {
+		if errCompile != nil {
+			return nil, ConfigError{Rule: "Regexp", Message: errCompile.Error()}
+		}
+
 		issue := Issue{
 			Code:    CODE_REGEXP,
 			Message: "The input doesn't match the required pattern",
@@ -537,15 +639,15 @@ func Regexp(pattern string) Rule {
 			return nil, issue
 		}
 
-		match, errMatch := regexp.MatchString(pattern, asString)
-		if errMatch != nil || !match {
+		if !compiled.MatchString(asString) {
 			return nil, issue
 		}
 		return asString, nil
 	}
 }
 
-// UUID check if the provided input is a valid string and a valid UUID.
+// UUID check if the provided input is a valid string and a valid UUID. Input
+// is matched case-insensitively and the returned value is always lower-case.
 func UUID(input any) (any, error) {
 	issue := Issue{
 		Code:    CODE_UUID,
@@ -560,7 +662,7 @@ func UUID(input any) (any, error) {
 	if errMatch != nil || !match {
 		return nil, issue
 	}
-	return asString, nil
+	return strings.ToLower(asString), nil
 }
 
 // Password check if the provided input is a valid string and a reasonably strong
@@ -585,7 +687,8 @@ func Password(input any) (any, error) {
 	return asString, nil
 }
 
-// JSON check if the provided code is a valid string and a valid json.
+// JSON check if the provided code is a valid string and a valid json. See
+// DecodeJSON to also decode the value and validate it.
 func JSON(input any) (any, error) {
 	issue := Issue{
 		Code:    CODE_JSON,
@@ -664,97 +767,78 @@ func Time(input any) (any, error) {
 }
 
 // DateEqual check if the provided date is a date equal to the target date.
+//
+// Deprecated: use Equals with a time.Time target value instead.
 func DateEqual(target time.Time) Rule {
-	return func(input any) (any, error) {
-		inputAsTime, ok := input.(time.Time)
-		if !ok {
-			return nil, errors.New("please provide a valid date")
-		}
-
-		delta := inputAsTime.Sub(target)
-		if delta != 0 {
-			return nil, Issue{
-				Code:    CODE_DATE_EQUAL,
-				Message: "The provided date must be " + target.String(),
-				Value:   target.String(),
-			}
-		}
-
-		return inputAsTime, nil
-	}
+	return withCode(CODE_DATE_EQUAL, Equals(target.String(), target))
 }
 
 // DateBefore check if the provided input is a date before (but not equal) to
 // the target date.
-// TODO: merge into LessThan
+//
+// Deprecated: use LessThan, or Max when inclusive, with a time.Time target
+// instead.
 func DateBefore(target time.Time, inclusive bool) Rule {
-	return func(input any) (any, error) {
-		inputAsTime, ok := input.(time.Time)
-		if !ok {
-			return nil, errors.New("please provide a valid date")
-		}
-
-		delta := inputAsTime.Sub(target)
-		if !inclusive && delta >= 0 {
-			return nil, Issue{
-				Code:    CODE_DATE_BEFORE,
-				Message: "The provided date must be before " + target.String(),
-				Value:   target.String(),
-			}
-		}
-
-		if inclusive && delta > 0 {
-			return nil, Issue{
-				Code:    CODE_DATE_BEFORE,
-				Message: "The provided date must be before or equal to " + target.String(),
-				Value:   target.String(),
-			}
-		}
-
-		return inputAsTime, nil
+	if inclusive {
+		return withCode(CODE_DATE_BEFORE, Max(target))
 	}
+	return withCode(CODE_DATE_BEFORE, LessThan(target))
 }
 
 // DateAfter check if the provided input is a date after the target date. If
 // inclusive is set to true, target date will be included.
-// TODO: merge into GreaterThan
+//
+// Deprecated: use GreaterThan, or Min when inclusive, with a time.Time target
+// instead.
 func DateAfter(target time.Time, inclusive bool) Rule {
+	if inclusive {
+		return withCode(CODE_DATE_AFTER, Min(target))
+	}
+	return withCode(CODE_DATE_AFTER, GreaterThan(target))
+}
+
+// withCode replaces the code of the issues reported by the provided rule,
+// which keeps the codes of the deprecated date rules unchanged.
+func withCode(code string, rule Rule) Rule {
 	return func(input any) (any, error) {
-		inputAsTime, ok := input.(time.Time)
-		if !ok {
-			return nil, errors.New("please provide a valid date")
+		output, err := rule(input)
+		if err == nil {
+			return output, nil
 		}
 
-		delta := inputAsTime.Sub(target)
-		if !inclusive && delta <= 0 {
-			return nil, Issue{
-				Code:    CODE_DATE_AFTER,
-				Message: "The provided date must be after " + target.String(),
-				Value:   target.String(),
-			}
+		if _, ok := asConfigError(err, ""); ok {
+			return nil, err
 		}
 
-		if inclusive && delta < 0 {
-			return nil, Issue{
-				Code:    CODE_DATE_AFTER,
-				Message: "The provided date must be after or equal to " + target.String(),
-				Value:   target.String(),
-			}
+		issues := Issues(err)
+		errs := make([]error, len(issues))
+		for i, issue := range issues {
+			issue.Code = code
+			errs[i] = issue
 		}
 
-		return inputAsTime, nil
+		if len(errs) == 1 {
+			return output, errs[0]
+		}
+		return output, errors.Join(errs...)
 	}
 }
 
-// Latitude check if the provided input a valid map latitude value.
+// Latitude check if the provided input a valid map latitude value. The input
+// is either a float32 or a float64, and is returned as it is.
 func Latitude(input any) (any, error) {
 	issue := Issue{
 		Code:    CODE_LATITUDE,
 		Message: "Please provide a valid latitude value",
 	}
 
-	inputAsFloat, ok := input.(float32)
-	if !ok {
+	var inputAsFloat float64
+	switch t := input.(type) {
+	case float32:
+		inputAsFloat = float64(t)
+	case float64:
+		inputAsFloat = t
+	default:
 		return nil, issue
 	}
 
@@ -762,18 +846,24 @@ func Latitude(input any) (any, error) {
 		return nil, issue
 	}
 
-	return inputAsFloat, nil
+	return input, nil
 }
 
-// Longitude check if the provided input a valid map longitude value.
+// Longitude check if the provided input a valid map longitude value. The
+// input is either a float32 or a float64, and is returned as it is.
 func Longitude(input any) (any, error) {
 	issue := Issue{
 		Code:    CODE_LONGITUDE,
 		Message: "Please provide a valid longitude value",
 	}
 
-	inputAsFloat, ok := input.(float32)
-	if !ok {
+	var inputAsFloat float64
+	switch t := input.(type) {
+	case float32:
+		inputAsFloat = float64(t)
+	case float64:
+		inputAsFloat = t
+	default:
 		return nil, issue
 	}
 
@@ -781,5 +871,67 @@ func Longitude(input any) (any, error) {
 		return nil, issue
 	}
 
-	return inputAsFloat, nil
+	return input, nil
+}
+
+// comparisonTargetError returns a ConfigError when the target of a comparison
+// rule such as Min is neither an int, a float64 nor a time.Time.
+func comparisonTargetError(rule string, target any) error {
+	switch target.(type) {
+	case int, float64, time.Time:
+		return nil
+	}
+
+	return ConfigError{
+		Rule:    rule,
+		Message: fmt.Sprintf("target must be an int, float64 or time.Time, got %T", target),
+	}
+}
+
+// compareTime checks a time.Time input against the target of a comparison
+// rule, returning the provided issue when the comparison fails.
+func compareTime(input any, issue Issue, valid func(time.Time) bool) (any, error) {
+	inputAsTime, ok := input.(time.Time)
+	if !ok {
+		issue.Message = "Please provide a valid date"
+		return nil, issue
+	}
+
+	if !valid(inputAsTime) {
+		return nil, issue
+	}
+	return inputAsTime, nil
+}
+
+// normalizeNumber converts the sized integer and float types, e.g. the int64
+// returned by ToInt64, to the int and float64 handled by the comparison rules.
+// Unsigned integers too large for an int are returned as they are.
+func normalizeNumber(input any) any {
+	switch t := input.(type) {
+	case int8:
+		return int(t)
+	case int16:
+		return int(t)
+	case int32:
+		return int(t)
+	case int64:
+		return int(t)
+	case uint8:
+		return int(t)
+	case uint16:
+		return int(t)
+	case uint32:
+		return int(t)
+	case uint:
+		if uint64(t) <= math.MaxInt {
+			return int(t)
+		}
+	case uint64:
+		if t <= math.MaxInt {
+			return int(t)
+		}
+	case float32:
+		return float64(t)
+	}
+	return input
 }
@@ -0,0 +1,234 @@
+package vld
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Coercion rules convert loosely typed input, such as the strings read from
+// query strings, CSV files, environment variables and headers, into proper
+// types which are passed on to the next rule e.g. ToInt followed by Min(18).
+// Values which already have the target type are passed through.
+
+var (
+	defaultTruthy = []string{"1", "t", "true", "y", "yes", "on"}
+	defaultFalsy  = []string{"0", "f", "false", "n", "no", "off"}
+)
+
+// coerceInt converts the provided input into an int64 within the provided
+// bounds. Floats, e.g. numbers decoded from JSON, are only accepted when they
+// have no fractional part.
+func coerceInt(input any, bitSize int) (int64, bool) {
+	minimum, maximum := int64(math.MinInt64), int64(math.MaxInt64)
+	if bitSize == 32 {
+		minimum, maximum = math.MinInt32, math.MaxInt32
+	}
+
+	var asInt int64
+	switch t := normalizeNumber(input).(type) {
+	case int:
+		asInt = int64(t)
+	case float64:
+		if t != math.Trunc(t) || t < float64(minimum) || t >= -float64(minimum) {
+			return 0, false
+		}
+		asInt = int64(t)
+	case json.Number:
+		parsed, err := strconv.ParseInt(t.String(), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		asInt = parsed
+	case string:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(t), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		asInt = parsed
+	default:
+		return 0, false
+	}
+
+	if asInt < minimum || asInt > maximum {
+		return 0, false
+	}
+	return asInt, true
+}
+
+// ToInt check if the provided input is an integer, or a string containing
+// one, e.g. "42". The returned value is an int.
+func ToInt(input any) (any, error) {
+	asInt, ok := coerceInt(input, strconv.IntSize)
+	if !ok {
+		return nil, Issue{
+			Code:    CODE_INT,
+			Message: "Please provide a whole number",
+		}
+	}
+	return int(asInt), nil
+}
+
+// ToInt64 check if the provided input is an integer, or a string containing
+// one, e.g. "42". The returned value is an int64.
+func ToInt64(input any) (any, error) {
+	asInt, ok := coerceInt(input, 64)
+	if !ok {
+		return nil, Issue{
+			Code:    CODE_INT,
+			Message: "Please provide a whole number",
+		}
+	}
+	return asInt, nil
+}
+
+// ToFloat check if the provided input is a number, or a string containing
+// one, e.g. "4.5". The returned value is a float64. NaN and infinity are not
+// accepted.
+func ToFloat(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_FLOAT,
+		Message: "Please provide a number",
+	}
+
+	var asFloat float64
+	switch t := normalizeNumber(input).(type) {
+	case float64:
+		asFloat = t
+	case int:
+		asFloat = float64(t)
+	case json.Number:
+		parsed, err := t.Float64()
+		if err != nil {
+			return nil, issue
+		}
+		asFloat = parsed
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		if err != nil {
+			return nil, issue
+		}
+		asFloat = parsed
+	default:
+		return nil, issue
+	}
+
+	if math.IsNaN(asFloat) || math.IsInf(asFloat, 0) {
+		return nil, issue
+	}
+	return asFloat, nil
+}
+
+// ToBool check if the provided input is a bool, or a string representing one.
+// The strings "1", "t", "true", "y", "yes" and "on" are true, while "0", "f",
+// "false", "n", "no" and "off" are false (case-insensitive). The returned
+// value is a bool.
+func ToBool(input any) (any, error) {
+	return ToBoolFrom(defaultTruthy, defaultFalsy)(input)
+}
+
+// ToBoolFrom works like ToBool, but with the provided sets of strings which
+// represent true and false. Strings are matched case-insensitively.
+func ToBoolFrom(truthy []string, falsy []string) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_BOOL,
+			Message: "Please provide either " + strings.Join(truthy, ", ") + " or " + strings.Join(falsy, ", "),
+		}
+
+		switch t := input.(type) {
+		case bool:
+			return t, nil
+		case string:
+			value := strings.TrimSpace(t)
+			matches := func(candidate string) bool {
+				return strings.EqualFold(candidate, value)
+			}
+
+			switch {
+			case slices.ContainsFunc(truthy, matches):
+				return true, nil
+			case slices.ContainsFunc(falsy, matches):
+				return false, nil
+			}
+		}
+
+		return nil, issue
+	}
+}
+
+// ToDuration check if the provided input is a time.Duration, or a string
+// containing one in the format accepted by time.ParseDuration, e.g. "1h30m".
+// The returned value is a time.Duration.
+func ToDuration(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_DURATION,
+		Message: "Please provide a valid duration e.g. 1h30m",
+	}
+
+	switch t := input.(type) {
+	case time.Duration:
+		return t, nil
+	case string:
+		duration, err := time.ParseDuration(strings.TrimSpace(t))
+		if err != nil {
+			return nil, issue
+		}
+		return duration, nil
+	}
+
+	return nil, issue
+}
+
+// ToTime check if the provided input is a time.Time, or a string matching one
+// of the provided layouts, e.g. time.RFC3339 or "02/01/2006". Layouts are
+// tried in order. When no layouts are provided, time.RFC3339 is used. The
+// returned value is a time.Time.
+func ToTime(layouts ...string) Rule {
+	return ToTimeIn(time.UTC, layouts...)
+}
+
+// ToDecimal check if the provided input is an exact decimal number, e.g.
+// "12.50". Strings, json.Number, ints and floats are accepted. The returned
+// value is a Decimal.
+func ToDecimal(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_DECIMAL,
+		Message: "Please provide a valid decimal number",
+	}
+
+	number := normalizeNumber(input)
+	if asFloat32, ok := input.(float32); ok {
+		// float32 is formatted with its own precision, i.e. 0.1 rather than
+		// 0.10000000149011612.
+		number = strconv.FormatFloat(float64(asFloat32), 'f', -1, 32)
+	}
+
+	var asString string
+	switch t := number.(type) {
+	case Decimal:
+		return t, nil
+	case string:
+		asString = strings.TrimSpace(t)
+	case json.Number:
+		asString = t.String()
+	case int:
+		asString = strconv.Itoa(t)
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return nil, issue
+		}
+		asString = strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return nil, issue
+	}
+
+	decimal, err := ParseDecimal(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return decimal, nil
+}
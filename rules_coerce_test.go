@@ -0,0 +1,242 @@
+package vld
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+/**
+ * Rule: ToInt, ToInt64
+ *
+ */
+func TestToInt(t *testing.T) {
+	validInputs := []any{"42", " -7 ", 42, int64(42), 42.0, json.Number("42"), int32(42), uint8(42), uint(42), float32(42)}
+	for _, input := range validInputs {
+		v, err := ToInt(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if _, ok := v.(int); !ok {
+			t.Error(errInvalidReturnType)
+			return
+		}
+	}
+
+	invalidInputs := []any{"", "4.5", "forty-two", 4.5, json.Number("4.5"), "99999999999999999999", float32(4.5), uint64(math.MaxUint64), true, nil}
+	for _, input := range invalidInputs {
+		if _, err := ToInt(input); !errors.Is(err, ErrInt) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+func TestToInt64(t *testing.T) {
+	v, err := ToInt64("9007199254740993")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if v != int64(9007199254740993) {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	if _, err := ToInt64(1e19); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: ToFloat
+ *
+ */
+func TestToFloat(t *testing.T) {
+	validInputs := map[any]float64{"4.5": 4.5, " 1e3 ": 1000, 3: 3, json.Number("-0.25"): -0.25}
+	for input, expected := range validInputs {
+		v, err := ToFloat(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if v != expected {
+			t.Errorf("unexpected output %v for %v", v, input)
+			return
+		}
+	}
+
+	invalidInputs := []any{"", "abc", "NaN", "Inf", true}
+	for _, input := range invalidInputs {
+		if _, err := ToFloat(input); !errors.Is(err, ErrFloat) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: ToBool, ToBoolFrom
+ *
+ */
+func TestToBool(t *testing.T) {
+	validInputs := map[any]bool{"true": true, "Yes": true, " on ": true, "1": true, "false": false, "OFF": false, "n": false, true: true}
+	for input, expected := range validInputs {
+		v, err := ToBool(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if v != expected {
+			t.Errorf("unexpected output %v for %v", v, input)
+			return
+		}
+	}
+
+	invalidInputs := []any{"", "maybe", 1, nil}
+	for _, input := range invalidInputs {
+		if _, err := ToBool(input); !errors.Is(err, ErrBool) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+func TestToBoolFrom(t *testing.T) {
+	rule := ToBoolFrom([]string{"ja"}, []string{"nein"})
+	if v, err := rule("JA"); err != nil || v != true {
+		t.Error("custom truthy value must return true")
+		return
+	}
+
+	if v, err := rule("nein"); err != nil || v != false {
+		t.Error("custom falsy value must return false")
+		return
+	}
+
+	if _, err := rule("yes"); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: ToDuration
+ *
+ */
+func TestToDuration(t *testing.T) {
+	v, err := ToDuration("1h30m")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if v != 90*time.Minute {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	for _, input := range []any{"", "90", "1 hour", 90} {
+		if _, err := ToDuration(input); !errors.Is(err, ErrDuration) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: ToTime
+ *
+ */
+func TestToTime(t *testing.T) {
+	v, err := ToTime()("2024-02-29T10:00:00Z")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !v.(time.Time).Equal(time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)) {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	rule := ToTime("02/01/2006", time.DateOnly)
+	for _, input := range []string{"29/02/2024", "2024-02-29"} {
+		v, err := rule(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if !v.(time.Time).Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)) {
+			t.Error(errInvalidReturnType)
+			return
+		}
+	}
+
+	for _, input := range []any{"2024-02-30", "yesterday", 1709200800} {
+		if _, err := rule(input); !errors.Is(err, ErrDateTime) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: ToDecimal
+ *
+ */
+func TestToDecimal(t *testing.T) {
+	validInputs := map[any]string{"12.50": "12.50", " -0.5 ": "-0.5", 12: "12", 0.1: "0.1", json.Number("1e2"): "100", float32(0.1): "0.1", int16(-3): "-3"}
+	for input, expected := range validInputs {
+		v, err := ToDecimal(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		decimal, ok := v.(Decimal)
+		if !ok {
+			t.Error(errInvalidReturnType)
+			return
+		}
+
+		if decimal.String() != expected {
+			t.Errorf("unexpected output %s for %v", decimal, input)
+			return
+		}
+	}
+
+	for _, input := range []any{"", ".", "1.2.3", "1,5", "1e", true} {
+		if _, err := ToDecimal(input); !errors.Is(err, ErrDecimal) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+func TestCoercionChain(t *testing.T) {
+	err := Validate([]Validation{
+		{Tag: "age", Data: "17", Rules: []Rule{ToInt64, Min(18)}},
+		{Tag: "limit", Data: "25", Rules: []Rule{ToInt, Max(100)}},
+	})
+
+	var errValidation ValidationErrors
+	if !errors.As(err, &errValidation) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if errValidation.Errors["age"].Code != CODE_MIN || len(errValidation.Errors) != 1 {
+		t.Errorf("unexpected errors %v", errValidation.Errors)
+		return
+	}
+}
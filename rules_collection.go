@@ -0,0 +1,312 @@
+package vld
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Collection rules work on slices and arrays of any type. Issues about a
+// single element are reported with the index of the element as their path,
+// e.g. "tags[3]", so that the offending entry can be pointed out.
+
+// collection returns the provided input as a reflect.Value when it is a slice
+// or an array.
+func collection(input any) (reflect.Value, bool) {
+	value := reflect.ValueOf(input)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		return value, true
+	}
+	return reflect.Value{}, false
+}
+
+func collectionIssue(code string) Issue {
+	return Issue{
+		Code:    code,
+		Message: "Please provide a list",
+	}
+}
+
+// elementPath returns the path of the element at the provided index, followed
+// by the path of an issue within the element.
+func elementPath(index int, path string) string {
+	return fmt.Sprintf("[%d]%s", index, path)
+}
+
+// Each check if every element of the provided slice or array passes the
+// provided rules. Rules are run in order for each element, the same way as for
+// a Validation, and the issues of every failing element are reported with the
+// index of the element e.g. "tags[3]". The returned value is a slice of the
+// outputs of the rules, of the same type as the input when possible and
+// []any otherwise.
+func Each(rules ...Rule) Rule {
+	return func(input any) (any, error) {
+		value, ok := collection(input)
+		if !ok {
+			return nil, collectionIssue(CODE_EACH)
+		}
+
+		outputs := make([]any, value.Len())
+		var errs []error
+		failed := false
+		for i := range outputs {
+			output, issues, blocking, errConfig := runRules(rules, value.Index(i).Interface(), elementPath(i, ""))
+			if errConfig != nil {
+				return nil, errConfig
+			}
+
+			outputs[i] = output
+			errs = append(errs, issues...)
+			failed = failed || blocking
+		}
+
+		if failed {
+			return nil, errors.Join(errs...)
+		}
+		return collectionOutput(value, outputs), errors.Join(errs...)
+	}
+}
+
+// runRules runs the provided rules against an element of a collection, the
+// same way Validate does for a validation, and prefixes the path of the
+// reported issues with the provided path. It stops at the first blocking issue
+// and reports whether there was one. ConfigErrors are returned as they are.
+func runRules(rules []Rule, input any, path string) (any, []error, bool, error) {
+	var errs []error
+	for _, rule := range rules {
+		output, err := rule(input)
+		if err == nil {
+			input = output
+			continue
+		}
+
+		if _, ok := asConfigError(err, ""); ok {
+			return nil, nil, false, err
+		}
+
+		blocking := false
+		for _, issue := range Issues(err) {
+			issue.Path = path + issue.Path
+			errs = append(errs, issue)
+			blocking = blocking || issue.blocking()
+		}
+
+		if blocking {
+			return nil, errs, true, nil
+		}
+
+		// rules failing with advisory issues only may not return an output,
+		// in which case the input is passed on unchanged.
+		if output != nil {
+			input = output
+		}
+	}
+	return input, errs, false, nil
+}
+
+// collectionOutput returns the outputs as a slice of the same element type as
+// the provided collection, if all of them can be assigned to it.
+func collectionOutput(value reflect.Value, outputs []any) any {
+	elementType := value.Type().Elem()
+	typed := reflect.MakeSlice(reflect.SliceOf(elementType), len(outputs), len(outputs))
+	for i, output := range outputs {
+		if output == nil {
+			if !isNillable(elementType.Kind()) {
+				return outputs
+			}
+			continue
+		}
+
+		outputValue := reflect.ValueOf(output)
+		if !outputValue.Type().AssignableTo(elementType) {
+			return outputs
+		}
+		typed.Index(i).Set(outputValue)
+	}
+	return typed.Interface()
+}
+
+func isNillable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Interface, reflect.Pointer, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
+		return true
+	}
+	return false
+}
+
+// MinItems check if the provided slice or array has at least the provided
+// number of elements.
+func MinItems(count int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_MIN_ITEMS,
+			Message: fmt.Sprintf("Please provide at least %d items", count),
+			Value:   count,
+		}
+
+		value, ok := collection(input)
+		if !ok {
+			return nil, collectionIssue(CODE_MIN_ITEMS)
+		}
+
+		if value.Len() < count {
+			return nil, issue
+		}
+		return input, nil
+	}
+}
+
+// MaxItems check if the provided slice or array has at most the provided
+// number of elements.
+func MaxItems(count int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_MAX_ITEMS,
+			Message: fmt.Sprintf("Please provide at most %d items", count),
+			Value:   count,
+		}
+
+		value, ok := collection(input)
+		if !ok {
+			return nil, collectionIssue(CODE_MAX_ITEMS)
+		}
+
+		if value.Len() > count {
+			return nil, issue
+		}
+		return input, nil
+	}
+}
+
+// Unique check if the elements of the provided slice or array are all
+// different from each other. Every duplicate is reported with its index.
+func Unique(input any) (any, error) {
+	return UniqueBy(func(element any) any {
+		return element
+	})(input)
+}
+
+// UniqueBy check if the provided key function returns a different key for
+// every element of the provided slice or array, e.g. to check that a list of
+// users doesn't contain the same e-mail address twice. Every duplicate is
+// reported with its index.
+func UniqueBy(key func(any) any) Rule {
+	return func(input any) (any, error) {
+		value, ok := collection(input)
+		if !ok {
+			return nil, collectionIssue(CODE_UNIQUE)
+		}
+
+		// comparable keys are looked up in a map, while other keys such as
+		// slices are compared one by one.
+		seen := make(map[any]int)
+		var others []any
+		var errs []error
+		for i := 0; i < value.Len(); i++ {
+			k := key(value.Index(i).Interface())
+
+			// the value is checked rather than its type, since a struct with
+			// an interface field holding a slice can't be hashed either.
+			comparable := k == nil || reflect.ValueOf(k).Comparable()
+			first, exists := -1, false
+			if comparable {
+				if first, exists = seen[k]; !exists {
+					seen[k] = i
+				}
+			} else {
+				for j, other := range others {
+					if reflect.DeepEqual(other, k) {
+						first, exists = j, true
+						break
+					}
+				}
+			}
+
+			// others is indexed the same way as the collection, with nil for
+			// comparable keys and duplicates.
+			var other any
+			if !comparable && !exists {
+				other = k
+			}
+			others = append(others, other)
+
+			if exists {
+				errs = append(errs, Issue{
+					Code:    CODE_UNIQUE,
+					Message: "The items must be unique",
+					Value:   first,
+					Path:    elementPath(i, ""),
+				})
+			}
+		}
+
+		if len(errs) != 0 {
+			return nil, errors.Join(errs...)
+		}
+		return input, nil
+	}
+}
+
+// Contains check if the provided slice or array has at least one element
+// equal to the provided value.
+func Contains(target any) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_CONTAINS,
+			Message: fmt.Sprintf("The list must contain %v", target),
+			Value:   target,
+		}
+
+		value, ok := collection(input)
+		if !ok {
+			return nil, collectionIssue(CODE_CONTAINS)
+		}
+
+		for i := 0; i < value.Len(); i++ {
+			if reflect.DeepEqual(value.Index(i).Interface(), target) {
+				return input, nil
+			}
+		}
+		return nil, issue
+	}
+}
+
+// SubsetOf check if every element of the provided slice or array is one of the
+// provided values, e.g. SubsetOf("read", "write") for a list of permissions.
+// Every element which isn't allowed is reported with its index.
+func SubsetOf(allowed ...any) Rule {
+	return func(input any) (any, error) {
+		value, ok := collection(input)
+		if !ok {
+			return nil, collectionIssue(CODE_SUBSET_OF)
+		}
+
+		var errs []error
+		for i := 0; i < value.Len(); i++ {
+			element := value.Index(i).Interface()
+
+			found := false
+			for _, candidate := range allowed {
+				if reflect.DeepEqual(element, candidate) {
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				errs = append(errs, Issue{
+					Code:    CODE_SUBSET_OF,
+					Message: fmt.Sprintf("The item must match values %v", allowed),
+					Value:   allowed,
+					Path:    elementPath(i, ""),
+				})
+			}
+		}
+
+		if len(errs) != 0 {
+			return nil, errors.Join(errs...)
+		}
+		return input, nil
+	}
+}
@@ -0,0 +1,194 @@
+package vld
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/**
+ * Rule: Each
+ *
+ */
+func TestEach(t *testing.T) {
+	v, err := Each(TrimSpace, NonEmptyString, Lowercase)([]string{" Go ", "RUST"})
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(v, []string{"go", "rust"}) {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	// outputs of another type are returned as []any.
+	v, err = Each(ToInt)([2]string{"1", "2"})
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(v, []any{1, 2}) {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	_, err = Each(NonEmptyString)([]any{"go", "", 42})
+	issues := Issues(err)
+	if len(issues) != 2 || issues[0].Path != "[1]" || issues[1].Path != "[2]" {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+
+	if _, err := Each(NonEmptyString)("go"); !errors.Is(err, ErrEach) {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+}
+
+func TestEachNested(t *testing.T) {
+	_, err := Each(Each(Min(0)))([][]int{{1, 2}, {3, -4}})
+	issues := Issues(err)
+	if len(issues) != 1 || issues[0].Path != "[1][1]" {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+}
+
+func TestEachAdvisoryIssue(t *testing.T) {
+	nudge := func(input any) (any, error) {
+		return nil, Issue{Code: "nudge", Message: "Consider a shorter name", Severity: SEVERITY_WARNING}
+	}
+
+	v, err := Each(nudge, Uppercase)([]string{"go"})
+	if len(Issues(err)) != 1 || !reflect.DeepEqual(v, []string{"GO"}) {
+		t.Errorf("unexpected output %v with issues %v", v, Issues(err))
+		return
+	}
+}
+
+func TestEachConfigError(t *testing.T) {
+	_, err := Each(Min("3"))([]int{1})
+	var errConfig ConfigError
+	if !errors.As(err, &errConfig) {
+		t.Error("config errors must be returned as they are")
+		return
+	}
+}
+
+/**
+ * Rule: MinItems, MaxItems
+ *
+ */
+func TestMinMaxItems(t *testing.T) {
+	tags := []string{"go", "rust", "zig"}
+	if _, err := MinItems(3)(tags); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := MaxItems(3)(tags); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := MinItems(4)(tags); !errors.Is(err, ErrMinItems) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := MaxItems(2)(tags); !errors.Is(err, ErrMaxItems) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := MinItems(0)(nil); err == nil {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+}
+
+/**
+ * Rule: Unique, UniqueBy
+ *
+ */
+func TestUnique(t *testing.T) {
+	if _, err := Unique([]string{"go", "rust"}); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	_, err := Unique([]string{"go", "rust", "go", "go"})
+	issues := Issues(err)
+	if len(issues) != 2 || issues[0].Path != "[2]" || issues[1].Path != "[3]" || issues[0].Value != 0 {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+
+	// elements which can't be used as map keys are compared by value.
+	_, err = Unique([][]int{{1}, {2}, {1}})
+	issues = Issues(err)
+	if len(issues) != 1 || issues[0].Path != "[2]" {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+}
+
+func TestUniqueBy(t *testing.T) {
+	type User struct {
+		Name  string
+		Email string
+	}
+
+	users := []User{{"John", "john@site.com"}, {"Jane", "JOHN@site.com"}}
+	_, err := UniqueBy(func(user any) any {
+		return strings.ToLower(user.(User).Email)
+	})(users)
+	if !errors.Is(err, ErrUnique) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	// the key type is comparable, but its values holding a slice aren't.
+	type Key struct{ V any }
+	byKey := UniqueBy(func(input any) any { return Key{input} })
+	_, err = byKey([]any{[]int{1}, 1, []int{1}})
+	issues := Issues(err)
+	if len(issues) != 1 || issues[0].Path != "[2]" {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+}
+
+/**
+ * Rule: Contains, SubsetOf
+ *
+ */
+func TestContains(t *testing.T) {
+	if _, err := Contains("admin")([]string{"user", "admin"}); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := Contains("admin")([]string{"user"}); !errors.Is(err, ErrContains) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+func TestSubsetOf(t *testing.T) {
+	rule := SubsetOf("read", "write")
+	if _, err := rule([]string{"write", "read"}); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	_, err := rule([]string{"read", "delete"})
+	issues := Issues(err)
+	if len(issues) != 1 || issues[0].Path != "[1]" || issues[0].Code != CODE_SUBSET_OF {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+}
@@ -0,0 +1,415 @@
+package vld
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Content rules check strings holding structured content, and return the
+// decoded content so that it can be validated further.
+
+// contentBytes returns the provided input as bytes, when it is a string or a
+// byte slice.
+func contentBytes(input any) ([]byte, bool) {
+	switch t := input.(type) {
+	case string:
+		return []byte(t), true
+	case []byte:
+		return t, true
+	}
+	return nil, false
+}
+
+// decodeContent decodes the provided input and runs the provided rules against
+// the decoded value, the same way Each does for an element.
+func decodeContent(input any, issue Issue, decode func([]byte) (any, error), rules []Rule) (any, error) {
+	content, ok := contentBytes(input)
+	if !ok {
+		return nil, issue
+	}
+
+	decoded, err := decode(content)
+	if err != nil {
+		return nil, issue
+	}
+
+	output, errs, blocking, errConfig := runRules(rules, decoded, "")
+	if errConfig != nil {
+		return nil, errConfig
+	}
+
+	if blocking {
+		return nil, errors.Join(errs...)
+	}
+	return output, errors.Join(errs...)
+}
+
+// DecodeJSON check if the provided input is a string (or []byte) holding a
+// single valid JSON value, and returns the decoded value. Objects are decoded
+// to map[string]any, arrays to []any and numbers to float64, the same way as
+// json.Unmarshal. The provided rules are run against the decoded value, e.g.
+// DecodeJSON(Map(UNKNOWN_KEYS_STRICT, RequiredKey("name", NonEmptyString))),
+// and the path of their issues is kept.
+func DecodeJSON(rules ...Rule) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_JSON,
+			Message: "Please provide a valid JSON string",
+		}
+
+		return decodeContent(input, issue, func(content []byte) (any, error) {
+			decoder := json.NewDecoder(bytes.NewReader(content))
+
+			var decoded any
+			if err := decoder.Decode(&decoded); err != nil {
+				return nil, err
+			}
+
+			if _, err := decoder.Token(); err != io.EOF {
+				return nil, fmt.Errorf("unexpected data after the JSON value")
+			}
+			return decoded, nil
+		}, rules)
+	}
+}
+
+// DecodeYAML check if the provided input is a string (or []byte) holding a
+// single valid YAML document, and returns the decoded value. Mappings with
+// string keys are decoded to map[string]any and sequences to []any. The
+// provided rules are run against the decoded value, see DecodeJSON.
+func DecodeYAML(rules ...Rule) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_YAML,
+			Message: "Please provide a valid YAML document",
+		}
+
+		return decodeContent(input, issue, func(content []byte) (any, error) {
+			decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+			// io.EOF is returned for input without a document, e.g. an empty
+			// or comment-only string, which is rejected the same way as by
+			// DecodeJSON.
+			var decoded any
+			if err := decoder.Decode(&decoded); err != nil {
+				return nil, err
+			}
+
+			var next any
+			if err := decoder.Decode(&next); err != io.EOF {
+				return nil, fmt.Errorf("unexpected document after the YAML document")
+			}
+			return decoded, nil
+		}, rules)
+	}
+}
+
+// XML check if the provided input is a string holding well-formed XML, with a
+// single root element.
+func XML(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_XML,
+		Message: "Please provide a valid XML document",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(asString))
+	roots, depth := 0, 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, issue
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && len(bytes.TrimSpace(t)) != 0 {
+				return nil, issue
+			}
+		}
+	}
+
+	if roots != 1 {
+		return nil, issue
+	}
+	return asString, nil
+}
+
+// CSV check if the provided input is a string holding comma-separated values,
+// every record having the provided number of columns. When a header is
+// provided, the first record must match it and the number of columns defaults
+// to its length. A number of columns of zero requires every record to have as
+// many columns as the first one. The returned value holds the records without
+// the header, as [][]string.
+func CSV(columns int, header ...string) Rule {
+	var errConfig error
+	switch {
+	case columns < 0:
+		errConfig = ConfigError{Rule: "CSV", Message: "number of columns cannot be negative"}
+	case len(header) != 0 && columns == 0:
+		columns = len(header)
+	case len(header) != 0 && columns != len(header):
+		errConfig = ConfigError{Rule: "CSV", Message: fmt.Sprintf("header has %d columns instead of %d", len(header), columns)}
+	}
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		issue := Issue{
+			Code:    CODE_CSV,
+			Message: "Please provide valid comma-separated values",
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		reader := csv.NewReader(strings.NewReader(asString))
+		reader.FieldsPerRecord = columns
+
+		records, err := reader.ReadAll()
+		if err != nil {
+			var errParse *csv.ParseError
+			if errors.As(err, &errParse) && errors.Is(errParse.Err, csv.ErrFieldCount) {
+				issue.Message = fmt.Sprintf("Line %d must have %d columns", errParse.Line, reader.FieldsPerRecord)
+				issue.Value = errParse.Line
+			}
+			return nil, issue
+		}
+
+		if len(header) == 0 {
+			return records, nil
+		}
+
+		if len(records) == 0 || !slices.Equal(records[0], header) {
+			issue.Message = fmt.Sprintf("The header must be %s", strings.Join(header, ","))
+			issue.Value = header
+			return nil, issue
+		}
+		return records[1:], nil
+	}
+}
+
+// decodedLength checks the length of decoded bytes against the provided
+// limits, a maximum of zero meaning no limit. Issues carry the code of the
+// encoding, e.g. CODE_BASE64, with the limit as their value.
+func decodedLength(code string, length int, minBytes int, maxBytes int) error {
+	if length < minBytes {
+		return Issue{
+			Code:    code,
+			Message: fmt.Sprintf("The decoded value must be at least %d bytes", minBytes),
+			Value:   minBytes,
+		}
+	}
+
+	if maxBytes != 0 && length > maxBytes {
+		return Issue{
+			Code:    code,
+			Message: fmt.Sprintf("The decoded value must be at most %d bytes", maxBytes),
+			Value:   maxBytes,
+		}
+	}
+	return nil
+}
+
+// Base64 check if the provided input is a string encoded using the provided
+// base64 encoding, e.g. base64.StdEncoding or base64.RawURLEncoding, whose
+// decoded length is between the provided number of bytes. A maximum of zero
+// means no limit. The returned value is the decoded []byte.
+func Base64(encoding *base64.Encoding, minBytes int, maxBytes int) Rule {
+	return func(input any) (any, error) {
+		if encoding == nil {
+			return nil, ConfigError{Rule: "Base64", Message: "encoding cannot be nil"}
+		}
+
+		issue := Issue{
+			Code:    CODE_BASE64,
+			Message: "Please provide a valid base64 string",
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		// the maximum is checked before decoding, so that large inputs aren't
+		// decoded only to be rejected. Padding makes the decoded value up to
+		// 2 bytes shorter than DecodedLen.
+		if err := decodedLength(CODE_BASE64, encoding.DecodedLen(len(asString))-2, 0, maxBytes); err != nil {
+			return nil, err
+		}
+
+		decoded, err := encoding.Strict().DecodeString(asString)
+		if err != nil {
+			return nil, issue
+		}
+
+		if err := decodedLength(CODE_BASE64, len(decoded), minBytes, maxBytes); err != nil {
+			return nil, err
+		}
+		return decoded, nil
+	}
+}
+
+// Hex check if the provided input is a hexadecimal string, in upper or lower
+// case, whose decoded length is between the provided number of bytes. A
+// maximum of zero means no limit. The returned value is the decoded []byte.
+func Hex(minBytes int, maxBytes int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_HEX,
+			Message: "Please provide a valid hexadecimal string",
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		// the maximum is checked before decoding, so that large inputs aren't
+		// decoded only to be rejected.
+		if err := decodedLength(CODE_HEX, hex.DecodedLen(len(asString)), 0, maxBytes); err != nil {
+			return nil, err
+		}
+
+		decoded, err := hex.DecodeString(asString)
+		if err != nil {
+			return nil, issue
+		}
+
+		if err := decodedLength(CODE_HEX, len(decoded), minBytes, maxBytes); err != nil {
+			return nil, err
+		}
+		return decoded, nil
+	}
+}
+
+// ParsedDataURI is a data URI parsed by DataURI.
+type ParsedDataURI struct {
+	// MediaType is the lower-cased MIME type e.g. "image/png". It defaults to
+	// "text/plain" when the URI doesn't have one.
+	MediaType string
+
+	// Params holds the parameters of the media type e.g. "charset".
+	Params map[string]string
+
+	Data []byte
+}
+
+// mediaTypeAllowed reports whether the provided media type matches one of the
+// allowed ones. A type ending with "/*", e.g. "image/*", matches all of its
+// subtypes.
+func mediaTypeAllowed(mediaType string, allowed []string) bool {
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(pattern)
+		if pattern == mediaType || (strings.HasSuffix(pattern, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDataURI parses a data URI as described by RFC 2397 e.g.
+// "data:image/png;base64,iVBORw0KGgo=".
+func parseDataURI(input string) (ParsedDataURI, bool) {
+	scheme, rest, found := strings.Cut(input, ":")
+	if !found || !strings.EqualFold(scheme, "data") {
+		return ParsedDataURI{}, false
+	}
+
+	header, payload, found := strings.Cut(rest, ",")
+	if !found {
+		return ParsedDataURI{}, false
+	}
+
+	encoded := false
+	if strings.HasSuffix(strings.ToLower(header), ";base64") {
+		encoded = true
+		header = header[:len(header)-len(";base64")]
+	}
+
+	if header == "" || strings.HasPrefix(header, ";") {
+		header = "text/plain" + header
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header)
+	if err != nil || !strings.Contains(mediaType, "/") {
+		return ParsedDataURI{}, false
+	}
+
+	unescaped, err := url.PathUnescape(payload)
+	if err != nil {
+		return ParsedDataURI{}, false
+	}
+
+	data := []byte(unescaped)
+	if encoded {
+		if data, err = base64.StdEncoding.DecodeString(unescaped); err != nil {
+			return ParsedDataURI{}, false
+		}
+	}
+
+	return ParsedDataURI{MediaType: mediaType, Params: params, Data: data}, true
+}
+
+// DataURI check if the provided input is a valid data URI, e.g.
+// "data:image/png;base64,iVBORw0KGgo=", whose media type is one of the
+// provided ones. Types ending with "/*", e.g. "image/*", allow all of their
+// subtypes, and providing no type allows any. The returned value is a
+// ParsedDataURI holding the decoded data.
+func DataURI(mediaTypes ...string) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_DATA_URI,
+			Message: "Please provide a valid data URI",
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		parsed, ok := parseDataURI(asString)
+		if !ok {
+			return nil, issue
+		}
+
+		if len(mediaTypes) != 0 && !mediaTypeAllowed(parsed.MediaType, mediaTypes) {
+			issue.Message = fmt.Sprintf("Please provide a data URI of type %s", strings.Join(mediaTypes, ", "))
+			issue.Value = mediaTypes
+			return nil, issue
+		}
+		return parsed, nil
+	}
+}
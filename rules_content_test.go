@@ -0,0 +1,277 @@
+package vld
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/**
+ * Rule: DecodeJSON
+ *
+ */
+func TestDecodeJSON(t *testing.T) {
+	v, err := DecodeJSON()(`{"name": "john", "tags": ["a", "b"], "age": 30}`)
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	expected := map[string]any{"name": "john", "tags": []any{"a", "b"}, "age": float64(30)}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("unexpected decoded value %v", v)
+		return
+	}
+
+	if _, err := DecodeJSON()([]byte(`[1, 2]`)); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	for _, input := range []any{"", "{", `{"a": 1} {"b": 2}`, "[1,]", 42} {
+		if _, err := DecodeJSON()(input); !errors.Is(err, ErrJSON) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+func TestDecodeJSONSchema(t *testing.T) {
+	rule := DecodeJSON(Map(UNKNOWN_KEYS_STRICT,
+		RequiredKey("name", NonEmptyString),
+		OptionalKey("tags", Each(NonEmptyString)),
+	))
+
+	if _, err := rule(`{"name": "john", "tags": ["a"]}`); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	_, err := rule(`{"name": "john", "tags": ["a", ""]}`)
+	issues := Issues(err)
+	if len(issues) != 1 || issues[0].Path != ".tags[1]" {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+}
+
+/**
+ * Rule: DecodeYAML
+ *
+ */
+func TestDecodeYAML(t *testing.T) {
+	v, err := DecodeYAML(Map(UNKNOWN_KEYS_STRICT, RequiredKey("name", NonEmptyString)))("name: john\n")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(v, map[string]any{"name": "john"}) {
+		t.Errorf("unexpected decoded value %v", v)
+		return
+	}
+
+	for _, input := range []any{"name: [john", "a: 1\n---\nb: 2\n", "", "  \n", "# just a comment\n", 42} {
+		if _, err := DecodeYAML()(input); !errors.Is(err, ErrYAML) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: XML
+ *
+ */
+func TestXML(t *testing.T) {
+	valid := []string{
+		`<note><to>John</to></note>`,
+		`<?xml version="1.0"?>` + "\n" + `<note a="1"/>` + "\n",
+	}
+	for _, input := range valid {
+		if _, err := XML(input); err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+	}
+
+	invalid := []string{"", "note", "<note>", "<a></b>", "<a/><b/>", "<a/>text"}
+	for _, input := range invalid {
+		if _, err := XML(input); !errors.Is(err, ErrXML) {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: CSV
+ *
+ */
+func TestCSV(t *testing.T) {
+	v, err := CSV(0, "name", "age")("name,age\njohn,30\njane,28\n")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(v, [][]string{{"john", "30"}, {"jane", "28"}}) {
+		t.Errorf("unexpected records %v", v)
+		return
+	}
+
+	if _, err := CSV(0, "name", "age")("age,name\n30,john\n"); !errors.Is(err, ErrCSV) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	_, err = CSV(2)("a,b\nc\n")
+	var issue Issue
+	if !errors.As(err, &issue) || issue.Code != CODE_CSV || issue.Value != 2 {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+
+	if _, err := CSV(0)("a,b\nc,d,e\n"); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	var errConfig ConfigError
+	if _, err := CSV(3, "a", "b")("a,b"); !errors.As(err, &errConfig) {
+		t.Error("expected a config error")
+		return
+	}
+}
+
+/**
+ * Rule: Base64
+ *
+ */
+func TestBase64(t *testing.T) {
+	v, err := Base64(base64.StdEncoding, 1, 16)("aGVsbG8=")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if string(v.([]byte)) != "hello" {
+		t.Errorf("unexpected decoded value %v", v)
+		return
+	}
+
+	if _, err := Base64(base64.RawURLEncoding, 0, 0)("_-8"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	for _, input := range []any{"aGVsbG8", "_-8=", "not base64", 42} {
+		if _, err := Base64(base64.StdEncoding, 0, 0)(input); !errors.Is(err, ErrBase64) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+
+	if _, err := Base64(base64.StdEncoding, 0, 4)("aGVsbG8="); !errors.Is(err, ErrBase64) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := Base64(base64.StdEncoding, 6, 0)("aGVsbG8="); !errors.Is(err, ErrBase64) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	// padding is accounted for when checking the maximum before decoding.
+	if _, err := Base64(base64.StdEncoding, 0, 4)("aGVsbA=="); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	// oversized inputs are rejected before being decoded.
+	if _, err := Base64(base64.StdEncoding, 0, 16)(strings.Repeat("!", 1<<20)); !errors.Is(err, ErrBase64) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: Hex
+ *
+ */
+func TestHex(t *testing.T) {
+	v, err := Hex(4, 4)("DEADbeef")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(v, []byte{0xde, 0xad, 0xbe, 0xef}) {
+		t.Errorf("unexpected decoded value %v", v)
+		return
+	}
+
+	for _, input := range []any{"abc", "xyz0", 42} {
+		if _, err := Hex(0, 0)(input); !errors.Is(err, ErrHex) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+
+	if _, err := Hex(0, 2)("deadbeef"); !errors.Is(err, ErrHex) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	// oversized inputs are rejected before being decoded.
+	if _, err := Hex(0, 16)(strings.Repeat("z", 1<<20)); !errors.Is(err, ErrHex) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: DataURI
+ *
+ */
+func TestDataURI(t *testing.T) {
+	v, err := DataURI("image/*")("data:image/PNG;base64,aGVsbG8=")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	parsed := v.(ParsedDataURI)
+	if parsed.MediaType != "image/png" || string(parsed.Data) != "hello" {
+		t.Errorf("unexpected data URI %v", parsed)
+		return
+	}
+
+	v, err = DataURI()("data:,hello%20world")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	parsed = v.(ParsedDataURI)
+	if parsed.MediaType != "text/plain" || string(parsed.Data) != "hello world" {
+		t.Errorf("unexpected data URI %v", parsed)
+		return
+	}
+
+	v, err = DataURI("text/plain")("data:text/plain;charset=utf-8,hi")
+	if err != nil || v.(ParsedDataURI).Params["charset"] != "utf-8" {
+		t.Errorf("unexpected data URI %v, %v", v, err)
+		return
+	}
+
+	invalid := []any{"image/png;base64,aGVsbG8=", "data:image/png;base64", "data:image/png;base64,***", "data:text/html,<b>hi</b>", 42}
+	for _, input := range invalid {
+		if _, err := DataURI("image/png", "text/plain")(input); !errors.Is(err, ErrDataURI) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package vld
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Clock returns the current time. Rules which compare against the current
+// time, such as InFuture, call their clock every time they run, which keeps
+// them correct in long-running servers. Use time.Now in production, and a
+// fixed time in tests. A nil clock is the same as time.Now.
+type Clock func() time.Time
+
+func (clock Clock) now() time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+	return clock()
+}
+
+// dateIssue returns the issue of the date rules for input which isn't a
+// time.Time, e.g. a string which wasn't parsed using DateTime or ToTime.
+func dateIssue(code string) Issue {
+	return Issue{
+		Code:    code,
+		Message: "Please provide a valid date",
+	}
+}
+
+// ToTimeIn works like ToTime, but parses times without a time zone, e.g.
+// "2006-01-02 15:04", in the provided location instead of UTC.
+func ToTimeIn(location *time.Location, layouts ...string) Rule {
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339}
+	}
+
+	return func(input any) (any, error) {
+		if location == nil {
+			return nil, ConfigError{Rule: "ToTimeIn", Message: "location cannot be nil"}
+		}
+
+		issue := Issue{
+			Code:    CODE_DATE_TIME,
+			Message: "Please provide a valid date",
+			Value:   layouts,
+		}
+
+		switch t := input.(type) {
+		case time.Time:
+			return t, nil
+		case string:
+			for _, layout := range layouts {
+				parsed, err := time.ParseInLocation(layout, strings.TrimSpace(t), location)
+				if err == nil {
+					return parsed, nil
+				}
+			}
+		}
+
+		return nil, issue
+	}
+}
+
+// InFuture check if the provided input is a time.Time after the current time
+// returned by the provided clock.
+func InFuture(clock Clock) Rule {
+	return func(input any) (any, error) {
+		asTime, ok := input.(time.Time)
+		if !ok {
+			return nil, dateIssue(CODE_IN_FUTURE)
+		}
+
+		if !asTime.After(clock.now()) {
+			return nil, Issue{
+				Code:    CODE_IN_FUTURE,
+				Message: "The date must be in the future",
+			}
+		}
+		return asTime, nil
+	}
+}
+
+// InPast check if the provided input is a time.Time before the current time
+// returned by the provided clock.
+func InPast(clock Clock) Rule {
+	return func(input any) (any, error) {
+		asTime, ok := input.(time.Time)
+		if !ok {
+			return nil, dateIssue(CODE_IN_PAST)
+		}
+
+		if !asTime.Before(clock.now()) {
+			return nil, Issue{
+				Code:    CODE_IN_PAST,
+				Message: "The date must be in the past",
+			}
+		}
+		return asTime, nil
+	}
+}
+
+// WithinLast check if the provided input is a time.Time in the past, no
+// longer ago than the provided duration e.g. WithinLast(30*24*time.Hour, nil).
+func WithinLast(duration time.Duration, clock Clock) Rule {
+	return func(input any) (any, error) {
+		asTime, ok := input.(time.Time)
+		if !ok {
+			return nil, dateIssue(CODE_WITHIN)
+		}
+
+		now := clock.now()
+		if asTime.After(now) || asTime.Before(now.Add(-duration)) {
+			return nil, Issue{
+				Code:    CODE_WITHIN,
+				Message: fmt.Sprintf("The date must be within the last %s", duration),
+				Value:   duration.String(),
+			}
+		}
+		return asTime, nil
+	}
+}
+
+// WithinNext check if the provided input is a time.Time in the future, no
+// further ahead than the provided duration e.g. WithinNext(90*24*time.Hour,
+// nil) for a booking date.
+func WithinNext(duration time.Duration, clock Clock) Rule {
+	return func(input any) (any, error) {
+		asTime, ok := input.(time.Time)
+		if !ok {
+			return nil, dateIssue(CODE_WITHIN)
+		}
+
+		now := clock.now()
+		if asTime.Before(now) || asTime.After(now.Add(duration)) {
+			return nil, Issue{
+				Code:    CODE_WITHIN,
+				Message: fmt.Sprintf("The date must be within the next %s", duration),
+				Value:   duration.String(),
+			}
+		}
+		return asTime, nil
+	}
+}
+
+// age returns the age in whole years of someone born on the provided date,
+// at the provided time. People born on the 29th of February turn a year older
+// on the 1st of March in other years.
+func age(birthdate time.Time, now time.Time) int {
+	now = now.In(birthdate.Location())
+	years := now.Year() - birthdate.Year()
+	if now.Month() < birthdate.Month() || (now.Month() == birthdate.Month() && now.Day() < birthdate.Day()) {
+		years--
+	}
+	return years
+}
+
+// MinAge check if the provided input is a birthdate (time.Time) of someone
+// who is at least the provided number of years old e.g. MinAge(18, nil).
+func MinAge(years int, clock Clock) Rule {
+	return func(input any) (any, error) {
+		asTime, ok := input.(time.Time)
+		if !ok {
+			return nil, dateIssue(CODE_MIN_AGE)
+		}
+
+		if age(asTime, clock.now()) < years {
+			return nil, Issue{
+				Code:    CODE_MIN_AGE,
+				Message: fmt.Sprintf("You must be at least %d years old", years),
+				Value:   years,
+			}
+		}
+		return asTime, nil
+	}
+}
+
+// MaxAge check if the provided input is a birthdate (time.Time) of someone
+// who is at most the provided number of years old.
+func MaxAge(years int, clock Clock) Rule {
+	return func(input any) (any, error) {
+		asTime, ok := input.(time.Time)
+		if !ok {
+			return nil, dateIssue(CODE_MAX_AGE)
+		}
+
+		if age(asTime, clock.now()) > years {
+			return nil, Issue{
+				Code:    CODE_MAX_AGE,
+				Message: fmt.Sprintf("You must be at most %d years old", years),
+				Value:   years,
+			}
+		}
+		return asTime, nil
+	}
+}
+
+// InTimezone converts the provided time.Time to the provided location, which
+// is required before checking the local weekday or time of day using Weekdays
+// and BusinessHours.
+func InTimezone(location *time.Location) Rule {
+	return func(input any) (any, error) {
+		if location == nil {
+			return nil, ConfigError{Rule: "InTimezone", Message: "location cannot be nil"}
+		}
+
+		asTime, ok := input.(time.Time)
+		if !ok {
+			return nil, dateIssue(CODE_TIMEZONE)
+		}
+		return asTime.In(location), nil
+	}
+}
+
+// Weekdays check if the provided input is a time.Time on one of the provided
+// days of the week, in the location of the time (see InTimezone).
+func Weekdays(days ...time.Weekday) Rule {
+	names := make([]string, len(days))
+	for i, day := range days {
+		names[i] = day.String()
+	}
+
+	return func(input any) (any, error) {
+		asTime, ok := input.(time.Time)
+		if !ok {
+			return nil, dateIssue(CODE_WEEKDAY)
+		}
+
+		if !slices.Contains(days, asTime.Weekday()) {
+			return nil, Issue{
+				Code:    CODE_WEEKDAY,
+				Message: fmt.Sprintf("The date must be on %s", strings.Join(names, ", ")),
+				Value:   names,
+			}
+		}
+		return asTime, nil
+	}
+}
+
+// BusinessHours check if the provided input is a time.Time between the
+// provided start (inclusive) and end (exclusive) times of day, e.g.
+// BusinessHours("09:00", "17:30"), in the location of the time (see
+// InTimezone). An end before the start, e.g. "22:00" to "06:00", spans
+// midnight.
+func BusinessHours(start string, end string) Rule {
+	startTime, errStart := time.Parse("15:04", start)
+	endTime, errEnd := time.Parse("15:04", end)
+
+	var errConfig error
+	switch {
+	case errStart != nil:
+		errConfig = ConfigError{Rule: "BusinessHours", Message: fmt.Sprintf("invalid start time '%s'", start)}
+	case errEnd != nil:
+		errConfig = ConfigError{Rule: "BusinessHours", Message: fmt.Sprintf("invalid end time '%s'", end)}
+	case startTime.Equal(endTime):
+		errConfig = ConfigError{Rule: "BusinessHours", Message: "start and end times cannot be equal"}
+	}
+
+	startMinute := startTime.Hour()*60 + startTime.Minute()
+	endMinute := endTime.Hour()*60 + endTime.Minute()
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		asTime, ok := input.(time.Time)
+		if !ok {
+			return nil, dateIssue(CODE_BUSINESS_HOURS)
+		}
+
+		minute := asTime.Hour()*60 + asTime.Minute()
+		valid := minute >= startMinute && minute < endMinute
+		if endMinute < startMinute {
+			valid = minute >= startMinute || minute < endMinute
+		}
+
+		if !valid {
+			return nil, Issue{
+				Code:    CODE_BUSINESS_HOURS,
+				Message: fmt.Sprintf("The time must be between %s and %s", start, end),
+				Value:   []string{start, end},
+			}
+		}
+		return asTime, nil
+	}
+}
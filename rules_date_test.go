@@ -0,0 +1,241 @@
+package vld
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fixedClock returns a clock which always returns the provided time.
+func fixedClock(now time.Time) Clock {
+	return func() time.Time {
+		return now
+	}
+}
+
+var testNow = time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+/**
+ * Rule: Min, Max, GreaterThan, LessThan, Equals with time.Time targets
+ *
+ */
+func TestComparisonRulesWithTime(t *testing.T) {
+	target := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	before, after := target.Add(-time.Second), target.Add(time.Second)
+
+	testCases := []struct {
+		rule    Rule
+		valid   []time.Time
+		invalid []time.Time
+	}{
+		{rule: Min(target), valid: []time.Time{target, after}, invalid: []time.Time{before}},
+		{rule: Max(target), valid: []time.Time{target, before}, invalid: []time.Time{after}},
+		{rule: GreaterThan(target), valid: []time.Time{after}, invalid: []time.Time{target, before}},
+		{rule: LessThan(target), valid: []time.Time{before}, invalid: []time.Time{target, after}},
+		{rule: Equals("start", target), valid: []time.Time{target.In(time.FixedZone("UTC+2", 7200))}, invalid: []time.Time{after}},
+	}
+
+	for _, testCase := range testCases {
+		for _, input := range testCase.valid {
+			if _, err := testCase.rule(input); err != nil {
+				t.Errorf(errValidFailed, err.Error())
+				return
+			}
+		}
+
+		for _, input := range testCase.invalid {
+			if _, err := testCase.rule(input); err == nil {
+				t.Error(errInvalidPassed)
+				return
+			}
+		}
+
+		if _, err := testCase.rule("2024-03-01"); err == nil {
+			t.Error(errInvalidTypePassed)
+			return
+		}
+	}
+
+	if _, err := Min(target)(before); !errors.Is(err, ErrMin) {
+		t.Error("time comparisons must report the code of the rule")
+		return
+	}
+}
+
+/**
+ * Rule: ToTimeIn
+ *
+ */
+func TestToTimeIn(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	v, err := ToTimeIn(berlin, "2006-01-02 15:04")("2024-07-01 09:30")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !v.(time.Time).Equal(time.Date(2024, 7, 1, 7, 30, 0, 0, time.UTC)) {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	var errConfig ConfigError
+	if _, err := ToTimeIn(nil)("2024-07-01"); !errors.As(err, &errConfig) {
+		t.Error("nil location must be reported as a ConfigError")
+		return
+	}
+}
+
+/**
+ * Rule: InFuture, InPast, WithinLast, WithinNext
+ *
+ */
+func TestRelativeBounds(t *testing.T) {
+	clock := fixedClock(testNow)
+	yesterday, tomorrow := testNow.AddDate(0, 0, -1), testNow.AddDate(0, 0, 1)
+	month := 30 * 24 * time.Hour
+
+	testCases := []struct {
+		rule    Rule
+		valid   []time.Time
+		invalid []time.Time
+	}{
+		{rule: InFuture(clock), valid: []time.Time{tomorrow}, invalid: []time.Time{testNow, yesterday}},
+		{rule: InPast(clock), valid: []time.Time{yesterday}, invalid: []time.Time{testNow, tomorrow}},
+		{rule: WithinLast(month, clock), valid: []time.Time{yesterday, testNow.Add(-month)}, invalid: []time.Time{tomorrow, testNow.AddDate(0, -2, 0)}},
+		{rule: WithinNext(month, clock), valid: []time.Time{tomorrow, testNow}, invalid: []time.Time{yesterday, testNow.AddDate(0, 2, 0)}},
+	}
+
+	for _, testCase := range testCases {
+		for _, input := range testCase.valid {
+			if _, err := testCase.rule(input); err != nil {
+				t.Errorf(errValidFailed, err.Error())
+				return
+			}
+		}
+
+		for _, input := range testCase.invalid {
+			if _, err := testCase.rule(input); err == nil {
+				t.Errorf("%s: %s", input, errInvalidPassed)
+				return
+			}
+		}
+	}
+}
+
+func TestClockIsCalledOnEveryRun(t *testing.T) {
+	now := testNow
+	rule := InFuture(func() time.Time { return now })
+
+	if _, err := rule(testNow.Add(time.Hour)); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := rule(testNow.Add(time.Hour)); !errors.Is(err, ErrInFuture) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: MinAge, MaxAge
+ *
+ */
+func TestAge(t *testing.T) {
+	clock := fixedClock(testNow)
+
+	if _, err := MinAge(18, clock)(time.Date(2006, 3, 15, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := MinAge(18, clock)(time.Date(2006, 3, 16, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrMinAge) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := MaxAge(65, clock)(time.Date(1958, 3, 16, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := MaxAge(65, clock)(time.Date(1958, 3, 15, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrMaxAge) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	// born on a leap day, turns 18 on the 1st of March.
+	leapDay := time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC)
+	if _, err := MinAge(18, fixedClock(time.Date(2022, 2, 28, 12, 0, 0, 0, time.UTC)))(leapDay); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := MinAge(18, fixedClock(time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)))(leapDay); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+}
+
+/**
+ * Rule: InTimezone, Weekdays, BusinessHours
+ *
+ */
+func TestWeekdaysAndBusinessHours(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	rules := []Rule{
+		InTimezone(tokyo),
+		Weekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday),
+		BusinessHours("09:00", "17:30"),
+	}
+
+	run := func(input time.Time) error {
+		var data any = input
+		for _, rule := range rules {
+			var err error
+			if data, err = rule(data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Friday 10:00 in Tokyo.
+	if err := run(time.Date(2024, 3, 15, 1, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	// Friday 17:30 in Tokyo.
+	if err := run(time.Date(2024, 3, 15, 8, 30, 0, 0, time.UTC)); !errors.Is(err, ErrBusinessHours) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	// Friday 20:00 in UTC is Saturday in Tokyo.
+	if err := run(time.Date(2024, 3, 15, 20, 0, 0, 0, time.UTC)); !errors.Is(err, ErrWeekday) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+func TestBusinessHoursOvernight(t *testing.T) {
+	rule := BusinessHours("22:00", "06:00")
+	if _, err := rule(time.Date(2024, 3, 15, 23, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := rule(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	var errConfig ConfigError
+	if _, err := BusinessHours("9am", "5pm")(testNow); !errors.As(err, &errConfig) {
+		t.Error("invalid times must be reported as a ConfigError")
+		return
+	}
+}
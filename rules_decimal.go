@@ -0,0 +1,205 @@
+package vld
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Decimal rules accept the same input as ToDecimal, i.e. strings, json.Number,
+// ints, floats and Decimal values, and return a Decimal. Comparisons are
+// exact, unlike Min and Max which compare float64 values.
+
+// decimalTarget parses the target of a decimal rule, returning a ConfigError
+// when it isn't a valid decimal number.
+func decimalTarget(rule string, target string) (Decimal, error) {
+	decimal, err := ParseDecimal(target)
+	if err != nil {
+		return Decimal{}, ConfigError{Rule: rule, Message: err.Error()}
+	}
+	return decimal, nil
+}
+
+// DecimalDigits check if the provided input is a decimal number (see
+// ToDecimal) with at most the provided number of digits in total, and at most
+// the provided number of digits after the decimal point, the same way as a
+// SQL DECIMAL(precision, scale) column. Trailing zeros of the fraction are not
+// counted, i.e. 12.50 fits DECIMAL(3, 1).
+func DecimalDigits(precision int, scale int) Rule {
+	return func(input any) (any, error) {
+		if precision < 1 || scale < 0 || scale > precision {
+			return nil, ConfigError{Rule: "DecimalDigits", Message: fmt.Sprintf("invalid precision %d and scale %d", precision, scale)}
+		}
+
+		v, err := ToDecimal(input)
+		if err != nil {
+			return nil, err
+		}
+
+		decimal := v.(Decimal)
+		trimmed := decimal.trimmed()
+		if trimmed.scale > scale {
+			return nil, Issue{
+				Code:    CODE_DECIMAL_SCALE,
+				Message: fmt.Sprintf("The number must have at most %d decimal places", scale),
+				Value:   scale,
+			}
+		}
+
+		if trimmed.digits()-trimmed.scale > precision-scale {
+			return nil, Issue{
+				Code:    CODE_DECIMAL_DIGITS,
+				Message: fmt.Sprintf("The number must have at most %d digits before the decimal point", precision-scale),
+				Value:   precision,
+			}
+		}
+		return decimal, nil
+	}
+}
+
+// DecimalScale check if the provided input is a decimal number (see
+// ToDecimal) with at most the provided number of decimal places. Trailing
+// zeros are not counted, i.e. 12.50 has a single decimal place.
+func DecimalScale(scale int) Rule {
+	return func(input any) (any, error) {
+		if scale < 0 {
+			return nil, ConfigError{Rule: "DecimalScale", Message: "scale cannot be negative"}
+		}
+
+		v, err := ToDecimal(input)
+		if err != nil {
+			return nil, err
+		}
+
+		decimal := v.(Decimal)
+		if decimal.trimmed().scale > scale {
+			return nil, Issue{
+				Code:    CODE_DECIMAL_SCALE,
+				Message: fmt.Sprintf("The number must have at most %d decimal places", scale),
+				Value:   scale,
+			}
+		}
+		return decimal, nil
+	}
+}
+
+// DecimalMin check if the provided input is a decimal number (see ToDecimal)
+// greater than or equal to the provided target e.g. DecimalMin("0.01").
+func DecimalMin(target string) Rule {
+	targetDecimal, errConfig := decimalTarget("DecimalMin", target)
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		v, err := ToDecimal(input)
+		if err != nil {
+			return nil, err
+		}
+
+		decimal := v.(Decimal)
+		if decimal.Cmp(targetDecimal) < 0 {
+			return nil, Issue{
+				Code:    CODE_MIN,
+				Message: fmt.Sprintf("The number must be at least %s", target),
+				Value:   target,
+			}
+		}
+		return decimal, nil
+	}
+}
+
+// DecimalMax check if the provided input is a decimal number (see ToDecimal)
+// less than or equal to the provided target e.g. DecimalMax("9999.99").
+func DecimalMax(target string) Rule {
+	targetDecimal, errConfig := decimalTarget("DecimalMax", target)
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		v, err := ToDecimal(input)
+		if err != nil {
+			return nil, err
+		}
+
+		decimal := v.(Decimal)
+		if decimal.Cmp(targetDecimal) > 0 {
+			return nil, Issue{
+				Code:    CODE_MAX,
+				Message: fmt.Sprintf("The number must be at most %s", target),
+				Value:   target,
+			}
+		}
+		return decimal, nil
+	}
+}
+
+// DecimalMultipleOf check if the provided input is a decimal number (see
+// ToDecimal) which is an exact multiple of the provided step, e.g.
+// DecimalMultipleOf("0.05") for cash rounding.
+func DecimalMultipleOf(step string) Rule {
+	stepDecimal, errConfig := decimalTarget("DecimalMultipleOf", step)
+	if errConfig == nil && stepDecimal.unscaledValue().Sign() <= 0 {
+		errConfig = ConfigError{Rule: "DecimalMultipleOf", Message: "step must be greater than zero"}
+	}
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		v, err := ToDecimal(input)
+		if err != nil {
+			return nil, err
+		}
+
+		decimal := v.(Decimal)
+		quotient := decimal.Rat()
+		quotient.Quo(quotient, stepDecimal.Rat())
+		if !quotient.IsInt() {
+			return nil, Issue{
+				Code:    CODE_MULTIPLE_OF,
+				Message: fmt.Sprintf("The number must be a multiple of %s", step),
+				Value:   step,
+			}
+		}
+		return decimal, nil
+	}
+}
+
+// Money check if the provided input is an amount (see ToDecimal) in the
+// provided ISO 4217 currency, i.e. that it has no more decimal places than the
+// minor unit of the currency, e.g. 2 for USD and 0 for JPY. The returned value
+// is a Decimal with the scale of the currency, i.e. 12.5 USD is returned as
+// 12.50. The currency is usually the value of another field, the same way as
+// for Equals.
+func Money(currency string) Rule {
+	return func(input any) (any, error) {
+		currency := strings.ToUpper(strings.TrimSpace(currency))
+		minorUnits, ok := CurrencyMinorUnits(currency)
+		if !ok {
+			return nil, Issue{
+				Code:    CODE_CURRENCY,
+				Message: fmt.Sprintf("Currency '%s' is not supported", currency),
+				Value:   currency,
+			}
+		}
+
+		v, err := ToDecimal(input)
+		if err != nil {
+			return nil, err
+		}
+
+		decimal := v.(Decimal).trimmed()
+		if decimal.scale > minorUnits {
+			return nil, Issue{
+				Code:    CODE_MONEY,
+				Message: fmt.Sprintf("Amounts in %s must have at most %d decimal places", currency, minorUnits),
+				Value:   minorUnits,
+			}
+		}
+		return decimal.withScale(minorUnits), nil
+	}
+}
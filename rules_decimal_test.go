@@ -0,0 +1,159 @@
+package vld
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+/**
+ * Rule: DecimalDigits, DecimalScale
+ *
+ */
+func TestDecimalDigits(t *testing.T) {
+	rule := DecimalDigits(5, 2)
+	for _, input := range []any{"999.99", "12.50", "-0.05", "12.5000", json.Number("100")} {
+		v, err := rule(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if _, ok := v.(Decimal); !ok {
+			t.Error(errInvalidReturnType)
+			return
+		}
+	}
+
+	if _, err := rule("1000"); !errors.Is(err, ErrDecimalDigits) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := rule("1.005"); !errors.Is(err, ErrDecimalScale) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := rule("abc"); !errors.Is(err, ErrDecimal) {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+
+	var errConfig ConfigError
+	if _, err := DecimalDigits(2, 3)("1"); !errors.As(err, &errConfig) {
+		t.Error("invalid precision must be reported as a ConfigError")
+		return
+	}
+}
+
+func TestDecimalScale(t *testing.T) {
+	if _, err := DecimalScale(0)("12.000"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := DecimalScale(2)(0.125); !errors.Is(err, ErrDecimalScale) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: DecimalMin, DecimalMax
+ *
+ */
+func TestDecimalMinMax(t *testing.T) {
+	// 0.1 + 0.2 is 0.30000000000000004 as a float64.
+	if _, err := DecimalMax("0.3")("0.30"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := DecimalMax("0.3")("0.30000000000000001"); !errors.Is(err, ErrMax) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := DecimalMin("0.01")("0.01"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := DecimalMin("0.01")("0.009"); !errors.Is(err, ErrMin) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	var errConfig ConfigError
+	if _, err := DecimalMin("ten")("1"); !errors.As(err, &errConfig) {
+		t.Error("invalid target must be reported as a ConfigError")
+		return
+	}
+}
+
+/**
+ * Rule: DecimalMultipleOf
+ *
+ */
+func TestDecimalMultipleOf(t *testing.T) {
+	rule := DecimalMultipleOf("0.05")
+	for _, input := range []string{"1.05", "0", "-12.10", "3"} {
+		if _, err := rule(input); err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+	}
+
+	if _, err := rule("1.01"); !errors.Is(err, ErrMultipleOf) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	var errConfig ConfigError
+	if _, err := DecimalMultipleOf("0")("1"); !errors.As(err, &errConfig) {
+		t.Error("zero step must be reported as a ConfigError")
+		return
+	}
+}
+
+/**
+ * Rule: Money
+ *
+ */
+func TestMoney(t *testing.T) {
+	testCases := []struct {
+		currency string
+		amount   any
+		expected string
+	}{
+		{currency: "USD", amount: "12.5", expected: "12.50"},
+		{currency: "usd", amount: 12, expected: "12.00"},
+		{currency: "JPY", amount: "1500", expected: "1500"},
+		{currency: "KWD", amount: json.Number("1.250"), expected: "1.250"},
+		{currency: "EUR", amount: "9.900", expected: "9.90"},
+	}
+
+	for _, testCase := range testCases {
+		v, err := Money(testCase.currency)(testCase.amount)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if v.(Decimal).String() != testCase.expected {
+			t.Errorf("unexpected amount %s for %v %s", v, testCase.amount, testCase.currency)
+			return
+		}
+	}
+
+	if _, err := Money("JPY")("100.5"); !errors.Is(err, ErrMoney) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := Money("XYZ")("1"); !errors.Is(err, ErrCurrency) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
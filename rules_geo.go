@@ -0,0 +1,337 @@
+package vld
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GeoJSON geometry types supported by the GeoJSON rule.
+const (
+	GEOJSON_POINT       = "Point"
+	GEOJSON_LINE_STRING = "LineString"
+	GEOJSON_POLYGON     = "Polygon"
+)
+
+// geoNumber returns the provided number as a float64.
+func geoNumber(input any) (float64, bool) {
+	switch t := normalizeNumber(input).(type) {
+	case int:
+		return float64(t), true
+	case float64:
+		return t, true
+	}
+	return 0, false
+}
+
+// geoPoint returns the provided [lat, lng] pair as a point. Pairs are either
+// a GeoPoint, a [2]float64, or a slice of two numbers such as decoded JSON.
+func geoPoint(input any) (GeoPoint, bool) {
+	var pair []any
+	switch t := input.(type) {
+	case GeoPoint:
+		return t, t.valid()
+	case [2]float64:
+		pair = []any{t[0], t[1]}
+	case []float64:
+		for _, value := range t {
+			pair = append(pair, value)
+		}
+	case []any:
+		pair = t
+	}
+
+	if len(pair) != 2 {
+		return GeoPoint{}, false
+	}
+
+	lat, okLat := geoNumber(pair[0])
+	lng, okLng := geoNumber(pair[1])
+	point := GeoPoint{Lat: lat, Lng: lng}
+	return point, okLat && okLng && point.valid()
+}
+
+// Coordinates check if the provided input is a valid [lat, lng] pair, e.g.
+// []float64{48.8566, 2.3522} or the []any decoded from JSON. The returned
+// value is a GeoPoint.
+func Coordinates(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_COORDINATES,
+		Message: "Please provide valid coordinates",
+	}
+
+	point, ok := geoPoint(input)
+	if !ok {
+		return nil, issue
+	}
+	return point, nil
+}
+
+// WithinBoundingBox check if the provided input is a [lat, lng] pair (see
+// Coordinates) within the provided box, e.g. a delivery zone. The returned
+// value is a GeoPoint.
+func WithinBoundingBox(box BoundingBox) Rule {
+	return func(input any) (any, error) {
+		if !box.valid() {
+			return nil, ConfigError{Rule: "WithinBoundingBox", Message: fmt.Sprintf("invalid bounding box %v", box)}
+		}
+
+		issue := Issue{
+			Code:    CODE_BOUNDING_BOX,
+			Message: "The location is outside of the allowed area",
+			Value:   box,
+		}
+
+		point, ok := geoPoint(input)
+		if !ok {
+			issue.Message = "Please provide valid coordinates"
+			return nil, issue
+		}
+
+		if !box.Contains(point) {
+			return nil, issue
+		}
+		return point, nil
+	}
+}
+
+// WithinDistance check if the provided input is a [lat, lng] pair (see
+// Coordinates) within the provided distance, in kilometers, of the provided
+// point e.g. a store. Distances are measured along the surface of the Earth
+// using the haversine formula. The returned value is a GeoPoint.
+func WithinDistance(center GeoPoint, kilometers float64) Rule {
+	return func(input any) (any, error) {
+		if !center.valid() || kilometers < 0 {
+			return nil, ConfigError{Rule: "WithinDistance", Message: fmt.Sprintf("invalid center %v or distance %g", center, kilometers)}
+		}
+
+		issue := Issue{
+			Code:    CODE_DISTANCE,
+			Message: fmt.Sprintf("The location must be within %g km", kilometers),
+			Value:   kilometers,
+		}
+
+		point, ok := geoPoint(input)
+		if !ok {
+			issue.Message = "Please provide valid coordinates"
+			return nil, issue
+		}
+
+		if center.Distance(point) > kilometers {
+			return nil, issue
+		}
+		return point, nil
+	}
+}
+
+// geoJSONIssue returns an issue about the GeoJSON geometry at the provided
+// path.
+func geoJSONIssue(path string, message string) Issue {
+	return Issue{
+		Code:    CODE_GEOJSON,
+		Message: message,
+		Path:    path,
+	}
+}
+
+// geoJSONPositions returns the [lng, lat] positions of a GeoJSON coordinates
+// array. Positions may have a third element, the altitude.
+func geoJSONPositions(input any, path string) ([]GeoPoint, error) {
+	elements, ok := input.([]any)
+	if !ok {
+		return nil, geoJSONIssue(path, "The coordinates must be an array of positions")
+	}
+
+	positions := make([]GeoPoint, len(elements))
+	for i, element := range elements {
+		position, ok := element.([]any)
+		if !ok || len(position) < 2 || len(position) > 3 {
+			return nil, geoJSONIssue(path+elementPath(i, ""), "The position must be a [longitude, latitude] pair")
+		}
+
+		point, ok := geoPoint([]any{position[1], position[0]})
+		if !ok {
+			return nil, geoJSONIssue(path+elementPath(i, ""), "The position must be a [longitude, latitude] pair")
+		}
+		positions[i] = point
+	}
+	return positions, nil
+}
+
+// ringArea returns twice the signed area of a ring, which is positive when the
+// ring is counter-clockwise.
+func ringArea(ring []GeoPoint) float64 {
+	area := 0.0
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i].Lng*ring[i+1].Lat - ring[i+1].Lng*ring[i].Lat
+	}
+	return area
+}
+
+// checkGeoJSONGeometry checks the coordinates of a geometry of the provided
+// type.
+func checkGeoJSONGeometry(geometryType string, coordinates any) error {
+	switch geometryType {
+	case GEOJSON_POINT:
+		_, err := geoJSONPositions([]any{coordinates}, "")
+		if err != nil {
+			return geoJSONIssue(".coordinates", "The position must be a [longitude, latitude] pair")
+		}
+	case GEOJSON_LINE_STRING:
+		positions, err := geoJSONPositions(coordinates, ".coordinates")
+		if err != nil {
+			return err
+		}
+
+		if len(positions) < 2 {
+			return geoJSONIssue(".coordinates", "A line string must have at least 2 positions")
+		}
+	case GEOJSON_POLYGON:
+		rings, ok := coordinates.([]any)
+		if !ok || len(rings) == 0 {
+			return geoJSONIssue(".coordinates", "A polygon must have at least one ring")
+		}
+
+		for i, ring := range rings {
+			path := ".coordinates" + elementPath(i, "")
+			positions, err := geoJSONPositions(ring, path)
+			if err != nil {
+				return err
+			}
+
+			if len(positions) < 4 || positions[0] != positions[len(positions)-1] {
+				return geoJSONIssue(path, "A ring must have at least 4 positions, the last one being the same as the first one")
+			}
+
+			// the exterior ring is counter-clockwise and the holes are
+			// clockwise (RFC 7946, section 3.1.6).
+			area := ringArea(positions)
+			if i == 0 && area < 0 {
+				return geoJSONIssue(path, "The exterior ring must be counter-clockwise")
+			}
+			if i != 0 && area > 0 {
+				return geoJSONIssue(path, "The holes must be clockwise")
+			}
+		}
+	}
+	return nil
+}
+
+// GeoJSON check if the provided input is a GeoJSON geometry (RFC 7946) of
+// one of the provided types, e.g. GeoJSON(GEOJSON_POLYGON). Providing no type
+// allows Point, LineString and Polygon geometries. The input is either a JSON
+// string or an already decoded map[string]any (see DecodeJSON). Polygon rings
+// must be closed and follow the right-hand rule, i.e. the exterior ring is
+// counter-clockwise and the holes are clockwise. Issues are reported at the
+// path of the offending position e.g. ".coordinates[0][2]". The returned value
+// is the decoded geometry.
+func GeoJSON(types ...string) Rule {
+	supported := []string{GEOJSON_POINT, GEOJSON_LINE_STRING, GEOJSON_POLYGON}
+	var errConfig error
+	for _, geometryType := range types {
+		if !slices.Contains(supported, geometryType) {
+			errConfig = ConfigError{Rule: "GeoJSON", Message: fmt.Sprintf("unsupported geometry type '%s'", geometryType)}
+		}
+	}
+
+	if len(types) == 0 {
+		types = supported
+	}
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		issue := Issue{
+			Code:    CODE_GEOJSON,
+			Message: "Please provide a valid GeoJSON geometry",
+		}
+
+		geometry, ok := input.(map[string]any)
+		if asString, isString := input.(string); isString {
+			ok = json.Unmarshal([]byte(asString), &geometry) == nil && geometry != nil
+		}
+
+		if !ok {
+			return nil, issue
+		}
+
+		geometryType, _ := geometry["type"].(string)
+		if !slices.Contains(types, geometryType) {
+			issue.Message = fmt.Sprintf("The geometry must be a %s", strings.Join(types, ", "))
+			issue.Value = types
+			issue.Path = ".type"
+			return nil, issue
+		}
+
+		if err := checkGeoJSONGeometry(geometryType, geometry["coordinates"]); err != nil {
+			return nil, err
+		}
+		return geometry, nil
+	}
+}
+
+// Geohash check if the provided input is a valid geohash of up to 12
+// characters, e.g. "u4pruydqqvj". The returned value is the center of the
+// area it describes, as a GeoPoint.
+func Geohash(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_GEOHASH,
+		Message: "Please provide a valid geohash",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	point, err := DecodeGeohash(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return point, nil
+}
+
+// PlusCode check if the provided input is a valid full plus code (Open
+// Location Code), e.g. "8FVC9G8F+6X". The returned value is the center of the
+// area it describes, as a GeoPoint.
+func PlusCode(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_PLUS_CODE,
+		Message: "Please provide a valid plus code",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	point, err := ParsePlusCode(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return point, nil
+}
+
+// MGRS check if the provided input is a valid Military Grid Reference System
+// coordinate, e.g. "33UXP04" (see ParseMGRS). The returned value is the
+// center of the grid square it describes, as a GeoPoint.
+func MGRS(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_MGRS,
+		Message: "Please provide a valid MGRS coordinate",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	point, err := ParseMGRS(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return point, nil
+}
@@ -0,0 +1,192 @@
+package vld
+
+import (
+	"errors"
+	"testing"
+)
+
+/**
+ * Rule: Coordinates
+ *
+ */
+func TestCoordinates(t *testing.T) {
+	valid := []any{
+		[]float64{48.8566, 2.3522},
+		[2]float64{-33.8688, 151.2093},
+		[]any{float64(90), 180},
+		GeoPoint{Lat: 1, Lng: 2},
+	}
+	for _, input := range valid {
+		v, err := Coordinates(input)
+		if err != nil {
+			t.Errorf("%v: %s", input, err.Error())
+			return
+		}
+
+		if _, ok := v.(GeoPoint); !ok {
+			t.Error(errInvalidReturnType)
+			return
+		}
+	}
+
+	invalid := []any{[]float64{91, 0}, []float64{0, -181}, []float64{1}, []any{"1", "2"}, "48.8566,2.3522", nil}
+	for _, input := range invalid {
+		if _, err := Coordinates(input); !errors.Is(err, ErrCoordinates) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: WithinBoundingBox
+ *
+ */
+func TestWithinBoundingBox(t *testing.T) {
+	rule := WithinBoundingBox(BoundingBox{South: 40.49, West: -74.26, North: 40.92, East: -73.70})
+	v, err := rule([]float64{40.7128, -74.0060})
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if v != (GeoPoint{40.7128, -74.0060}) {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	for _, input := range []any{[]float64{34.0522, -118.2437}, []float64{100, 0}, "nyc"} {
+		if _, err := rule(input); !errors.Is(err, ErrBoundingBox) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+
+	var errConfig ConfigError
+	if _, err := WithinBoundingBox(BoundingBox{South: 10, North: 0})(nil); !errors.As(err, &errConfig) {
+		t.Error("expected a config error")
+		return
+	}
+}
+
+/**
+ * Rule: WithinDistance
+ *
+ */
+func TestWithinDistance(t *testing.T) {
+	store := GeoPoint{Lat: 51.5074, Lng: -0.1278}
+	rule := WithinDistance(store, 10)
+	if _, err := rule([]float64{51.5007, -0.1246}); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := rule([]float64{48.8566, 2.3522}); !errors.Is(err, ErrDistance) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	// chained after a rule returning a GeoPoint
+	if _, err := rule(GeoPoint{51.5, -0.12}); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	var errConfig ConfigError
+	if _, err := WithinDistance(store, -1)(nil); !errors.As(err, &errConfig) {
+		t.Error("expected a config error")
+		return
+	}
+}
+
+/**
+ * Rule: GeoJSON
+ *
+ */
+func TestGeoJSON(t *testing.T) {
+	valid := []any{
+		`{"type": "Point", "coordinates": [2.3522, 48.8566]}`,
+		`{"type": "Point", "coordinates": [2.3522, 48.8566, 35]}`,
+		`{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}`,
+		`{"type": "Polygon", "coordinates": [[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]], [[2, 2], [2, 4], [4, 4], [4, 2], [2, 2]]]}`,
+		map[string]any{"type": "Point", "coordinates": []any{float64(1), float64(2)}},
+	}
+	for _, input := range valid {
+		if _, err := GeoJSON()(input); err != nil {
+			t.Errorf("%v: %s", input, err.Error())
+			return
+		}
+	}
+
+	testCases := map[string]string{
+		`{"type": "Circle", "coordinates": [0, 0]}`:                                                                                    ".type",
+		`{"type": "Point", "coordinates": [200, 0]}`:                                                                                   ".coordinates",
+		`{"type": "LineString", "coordinates": [[0, 0]]}`:                                                                              ".coordinates",
+		`{"type": "LineString", "coordinates": [[0, 0], [0, 95]]}`:                                                                     ".coordinates[1]",
+		`{"type": "Polygon", "coordinates": [[[0, 0], [10, 0], [10, 10], [0, 10]]]}`:                                                   ".coordinates[0]",
+		`{"type": "Polygon", "coordinates": [[[0, 0], [0, 10], [10, 10], [10, 0], [0, 0]]]}`:                                           ".coordinates[0]",
+		`{"type": "Polygon", "coordinates": [[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]], [[2, 2], [4, 2], [4, 4], [2, 4], [2, 2]]]}`: ".coordinates[1]",
+		`not json`: "",
+	}
+	for input, path := range testCases {
+		_, err := GeoJSON()(input)
+		var issue Issue
+		if !errors.As(err, &issue) || issue.Code != CODE_GEOJSON {
+			t.Errorf("%s: %s", input, errInvalidPassed)
+			return
+		}
+
+		if issue.Path != path {
+			t.Errorf("%s: unexpected path %q, expected %q", input, issue.Path, path)
+			return
+		}
+	}
+
+	if _, err := GeoJSON(GEOJSON_POLYGON)(`{"type": "Point", "coordinates": [0, 0]}`); !errors.Is(err, ErrGeoJSON) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	var errConfig ConfigError
+	if _, err := GeoJSON("MultiPolygon")(nil); !errors.As(err, &errConfig) {
+		t.Error("expected a config error")
+		return
+	}
+}
+
+/**
+ * Rule: Geohash, PlusCode, MGRS
+ *
+ */
+func TestGeoCodes(t *testing.T) {
+	testCases := []struct {
+		rule    Rule
+		valid   string
+		invalid any
+		err     error
+	}{
+		{Geohash, "u4pruydqqvj", "u4pa", ErrGeohash},
+		{PlusCode, "8FVC9G8F+6X", "9G8F+6X", ErrPlusCode},
+		{MGRS, "33UXP04", "33UXP0", ErrMGRS},
+	}
+
+	for _, testCase := range testCases {
+		v, err := testCase.rule(testCase.valid)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if _, ok := v.(GeoPoint); !ok {
+			t.Error(errInvalidReturnType)
+			return
+		}
+
+		for _, input := range []any{testCase.invalid, 42} {
+			if _, err := testCase.rule(input); !errors.Is(err, testCase.err) {
+				t.Errorf("%v: %s", input, errInvalidPassed)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package vld
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// HTMLPolicy describes the HTML allowed in user-generated text, such as
+// comments, checked by SafeHTML and enforced by SanitizeHTML. Regardless of
+// the policy, <script> tags, event-handler attributes such as "onclick" and
+// "javascript:" links are never allowed.
+type HTMLPolicy struct {
+	// AllowedTags lists the allowed tags in lower-case e.g. "b". Text without
+	// any tag, such as markdown, is always allowed.
+	AllowedTags []string
+
+	// AllowedAttributes lists the attributes allowed on the allowed tags e.g.
+	// "href".
+	AllowedAttributes []string
+}
+
+// DefaultHTMLPolicy allows basic formatting, lists, quotes, code and links.
+var DefaultHTMLPolicy = HTMLPolicy{
+	AllowedTags: []string{
+		"a", "b", "blockquote", "br", "code", "em", "i", "li", "ol", "p", "pre",
+		"strong", "ul",
+	},
+	AllowedAttributes: []string{"href", "title"},
+}
+
+// htmlDroppedContent lists the tags whose content is removed along with them
+// by SanitizeHTML, rather than kept as text.
+var htmlDroppedContent = []string{
+	"iframe", "noembed", "noframes", "noscript", "object", "plaintext", "script",
+	"style", "template", "textarea", "title", "xmp",
+}
+
+// htmlURLAttributes lists the attributes holding a URL.
+var htmlURLAttributes = []string{
+	"action", "background", "cite", "formaction", "href", "poster", "src",
+	"xlink:href",
+}
+
+var (
+	markdownLinkPattern       = regexp.MustCompile(`(!?)\[[^\]]*\]\(\s*<?((?:[^\s()<>]|\([^\s()]*\))*)`)
+	markdownDefinitionPattern = regexp.MustCompile(`(?m)^ {0,3}\[[^\]]+\]:[ \t]*<?([^\s>]*)`)
+	bareURLPattern            = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"']+`)
+)
+
+// unsafeURL reports whether the provided URL runs a script when followed.
+// Browsers ignore white space and control characters within the scheme.
+func unsafeURL(url string) bool {
+	url = strings.Map(func(r rune) rune {
+		if r <= ' ' || unicode.IsControl(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, url)
+	return strings.HasPrefix(url, "javascript:") || strings.HasPrefix(url, "vbscript:")
+}
+
+// allowedAttribute reports whether the provided attribute of an allowed tag is
+// allowed by the policy.
+func (policy HTMLPolicy) allowedAttribute(attribute html.Attribute) bool {
+	key := strings.ToLower(attribute.Key)
+	if strings.HasPrefix(key, "on") || !slices.Contains(policy.AllowedAttributes, key) {
+		return false
+	}
+	return !slices.Contains(htmlURLAttributes, key) || !unsafeURL(attribute.Val)
+}
+
+// allowedTag reports whether the provided tag is allowed by the policy.
+func (policy HTMLPolicy) allowedTag(tag string) bool {
+	return tag != "script" && slices.Contains(policy.AllowedTags, tag)
+}
+
+// sanitizeMarkdown replaces the target of the markdown links which run a
+// script with "#", and reports whether there was any.
+func sanitizeMarkdown(text string) (string, bool) {
+	unsafe := false
+	for _, pattern := range []*regexp.Regexp{markdownLinkPattern, markdownDefinitionPattern} {
+		text = replaceSubmatch(pattern, text, func(url string) string {
+			if !unsafeURL(url) {
+				return url
+			}
+
+			unsafe = true
+			return "#"
+		})
+	}
+	return text, unsafe
+}
+
+// replaceSubmatch replaces the last submatch of each match of the provided
+// pattern.
+func replaceSubmatch(pattern *regexp.Regexp, text string, replace func(string) string) string {
+	var builder strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[len(match)-2], match[len(match)-1]
+		builder.WriteString(text[last:start])
+		builder.WriteString(replace(text[start:end]))
+		last = end
+	}
+	builder.WriteString(text[last:])
+	return builder.String()
+}
+
+// escapeText escapes the characters of the text of an HTML document which
+// could start a tag or an entity. Unlike html.EscapeString, quotes and ">" are
+// kept, which keeps markdown such as block quotes intact.
+var escapeText = strings.NewReplacer("&", "&amp;", "<", "&lt;").Replace
+
+// sanitize returns the provided HTML with the disallowed tags and attributes
+// removed, along with the disallowed tags found, sorted and without
+// duplicates. Tags with a disallowed attribute are reported as well.
+func (policy HTMLPolicy) sanitize(input string) (string, []string) {
+	var builder strings.Builder
+	var offending, open []string
+	report := func(tag string) {
+		if !slices.Contains(offending, tag) {
+			offending = append(offending, tag)
+		}
+	}
+
+	dropping, depth := "", 0
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		token := tokenizer.Token()
+		if dropping != "" {
+			switch {
+			case tokenType == html.StartTagToken && token.Data == dropping:
+				depth++
+			case tokenType == html.EndTagToken && token.Data == dropping:
+				depth--
+			}
+
+			if depth == 0 {
+				dropping = ""
+			}
+			continue
+		}
+
+		switch tokenType {
+		case html.TextToken:
+			text, unsafe := sanitizeMarkdown(token.Data)
+			if unsafe {
+				report("a")
+			}
+			builder.WriteString(escapeText(text))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if !policy.allowedTag(token.Data) {
+				report(token.Data)
+				if tokenType == html.StartTagToken && slices.Contains(htmlDroppedContent, token.Data) {
+					dropping, depth = token.Data, 1
+				}
+				continue
+			}
+
+			builder.WriteString("<" + token.Data)
+			for _, attribute := range token.Attr {
+				if !policy.allowedAttribute(attribute) {
+					report(token.Data)
+					continue
+				}
+				fmt.Fprintf(&builder, ` %s="%s"`, strings.ToLower(attribute.Key), html.EscapeString(attribute.Val))
+			}
+			builder.WriteString(">")
+
+			if tokenType == html.StartTagToken && !isVoidElement(token.Data) {
+				open = append(open, token.Data)
+			}
+		case html.EndTagToken:
+			// only the open tags are closed, which keeps the output balanced.
+			if i := slices.Index(open, token.Data); i != -1 {
+				for j := len(open) - 1; j >= i; j-- {
+					builder.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+			}
+		}
+	}
+
+	for j := len(open) - 1; j >= 0; j-- {
+		builder.WriteString("</" + open[j] + ">")
+	}
+
+	slices.Sort(offending)
+	return builder.String(), offending
+}
+
+// isVoidElement reports whether the provided tag never has content nor an end
+// tag, e.g. "br".
+func isVoidElement(tag string) bool {
+	return slices.Contains([]string{
+		"area", "base", "br", "col", "embed", "hr", "img", "input", "link",
+		"meta", "source", "track", "wbr",
+	}, tag)
+}
+
+// SafeHTML check if the provided input is a string holding only the HTML
+// allowed by the provided policy, e.g. SafeHTML(DefaultHTMLPolicy). Markdown
+// links running a script, e.g. "[x](javascript:alert(1))", aren't allowed
+// either. The issue lists the offending tags, see SanitizeHTML to remove them
+// instead.
+func SafeHTML(policy HTMLPolicy) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_HTML,
+			Message: "Please provide a valid string",
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		_, offending := policy.sanitize(asString)
+		if len(offending) != 0 {
+			issue.Message = fmt.Sprintf("The value cannot contain the HTML tags %s", strings.Join(offending, ", "))
+			issue.Value = offending
+			return nil, issue
+		}
+		return asString, nil
+	}
+}
+
+// SanitizeHTML removes the HTML not allowed by the provided policy from the
+// provided input, e.g. SanitizeHTML(DefaultHTMLPolicy). The content of the
+// removed tags is kept as text, except for tags such as <script> and <style>.
+// The targets of markdown links running a script are replaced with "#".
+func SanitizeHTML(policy HTMLPolicy) Rule {
+	return func(input any) (any, error) {
+		asString, ok := input.(string)
+		if !ok {
+			return input, nil
+		}
+
+		sanitized, _ := policy.sanitize(asString)
+		return sanitized, nil
+	}
+}
+
+// countLinks returns the number of links of the provided HTML or markdown
+// text: <a> tags with a target, markdown links and bare http(s) URLs.
+func countLinks(input string) int {
+	links, inLink := 0, false
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			return links
+		}
+
+		token := tokenizer.Token()
+		switch {
+		case token.Data == "a" && tokenType == html.StartTagToken:
+			inLink = slices.ContainsFunc(token.Attr, func(attribute html.Attribute) bool {
+				return strings.EqualFold(attribute.Key, "href")
+			})
+			if inLink {
+				links++
+			}
+		case token.Data == "a" && tokenType == html.EndTagToken:
+			inLink = false
+		case tokenType == html.TextToken && !inLink:
+			text := markdownLinkPattern.ReplaceAllStringFunc(token.Data, func(match string) string {
+				if !strings.HasPrefix(match, "!") {
+					links++
+				}
+				return ""
+			})
+			links += len(bareURLPattern.FindAllString(text, -1))
+		}
+	}
+}
+
+// MaxLinks check if the provided input is a string with at most the provided
+// number of links. Links are <a> tags with an href, markdown links, and bare
+// http(s) URLs.
+func MaxLinks(count int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_MAX_LINKS,
+			Message: fmt.Sprintf("The value cannot contain more than %d links", count),
+			Value:   count,
+		}
+
+		asString, ok := input.(string)
+		if !ok || countLinks(asString) > count {
+			return nil, issue
+		}
+		return asString, nil
+	}
+}
+
+// MaxLines check if the provided input is a string with at most the provided
+// number of lines. Trailing line breaks aren't counted.
+func MaxLines(count int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_MAX_LINES,
+			Message: fmt.Sprintf("The value cannot contain more than %d lines", count),
+			Value:   count,
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		text := strings.TrimRight(strings.ReplaceAll(asString, "\r\n", "\n"), "\r\n")
+		if text != "" && strings.Count(text, "\n")+1 > count {
+			return nil, issue
+		}
+		return asString, nil
+	}
+}
@@ -0,0 +1,145 @@
+package vld
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+/**
+ * Rule: SafeHTML
+ *
+ */
+func TestSafeHTML(t *testing.T) {
+	rule := SafeHTML(DefaultHTMLPolicy)
+	valid := []string{
+		"plain text with 1 < 2 & 3 > 2",
+		`<p>Hello <b>world</b>, see <a href="https://example.com" title="x">this</a></p>`,
+		"> a markdown quote with a [link](https://example.com)",
+	}
+	for _, input := range valid {
+		if _, err := rule(input); err != nil {
+			t.Errorf("%q: %s", input, err.Error())
+			return
+		}
+	}
+
+	testCases := map[string][]string{
+		`<script>alert(1)</script>`:                     {"script"},
+		`<p onclick="alert(1)">hi</p>`:                  {"p"},
+		`<a href=" JaVa&#09;script:alert(1)">x</a>`:     {"a"},
+		`<img src="x.png"><div>hi</div>`:                {"div", "img"},
+		`[click](javascript:alert(1))`:                  {"a"},
+		"[click][1]\n\n[1]: javascript:alert(1)":        {"a"},
+		`<b style="color: red">hi</b><SCRIPT></SCRIPT>`: {"b", "script"},
+	}
+	for input, expected := range testCases {
+		_, err := rule(input)
+		var issue Issue
+		if !errors.As(err, &issue) || issue.Code != CODE_HTML {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+
+		if !reflect.DeepEqual(issue.Value, expected) {
+			t.Errorf("%q: unexpected tags %v, expected %v", input, issue.Value, expected)
+			return
+		}
+	}
+
+	withScript := SafeHTML(HTMLPolicy{AllowedTags: []string{"script"}})
+	if _, err := withScript("<script></script>"); !errors.Is(err, ErrHTML) {
+		t.Error("script tags must never be allowed")
+		return
+	}
+
+	if _, err := rule(42); err == nil {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+}
+
+/**
+ * Rule: SanitizeHTML
+ *
+ */
+func TestSanitizeHTML(t *testing.T) {
+	rule := SanitizeHTML(DefaultHTMLPolicy)
+	testCases := map[string]string{
+		"plain text": "plain text",
+		`<p onclick="x()">Hi <div>there</div></p>`:        "<p>Hi there</p>",
+		`<b>bold<script>alert("1")</script></b>`:          "<b>bold</b>",
+		`<a href="javascript:alert(1)" title="t">x</a>`:   `<a title="t">x</a>`,
+		`<a href="https://example.com?a=1&b=2">x</a>`:     `<a href="https://example.com?a=1&amp;b=2">x</a>`,
+		"<b>unclosed <i>tags":                             "<b>unclosed <i>tags</i></b>",
+		"</p>stray end tag":                               "stray end tag",
+		"line<br/>break":                                  "line<br>break",
+		"[x](javascript:alert(1)) and [y](https://a.com)": "[x](#) and [y](https://a.com)",
+		"> quote & 1 &lt; 2":                              "> quote &amp; 1 &lt; 2",
+		`<embed src="x.swf"><b>kept</b>`:                  "<b>kept</b>",
+		"<style>p { color: red }</style>styled":           "styled",
+	}
+	for input, expected := range testCases {
+		v, err := rule(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if v != expected {
+			t.Errorf("%q: got %q, expected %q", input, v, expected)
+			return
+		}
+	}
+
+	if v, _ := rule(42); v != 42 {
+		t.Error("non-string input must be passed through")
+		return
+	}
+}
+
+/**
+ * Rule: MaxLinks
+ *
+ */
+func TestMaxLinks(t *testing.T) {
+	input := `<a href="https://a.com">https://a.com</a> [b](https://b.com) ![img](https://c.com/x.png) https://d.com <a name="top">top</a>`
+	if _, err := MaxLinks(3)(input); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := MaxLinks(2)(input); !errors.Is(err, ErrMaxLinks) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := MaxLinks(0)("no links at all"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+}
+
+/**
+ * Rule: MaxLines
+ *
+ */
+func TestMaxLines(t *testing.T) {
+	valid := []string{"", "one", "one\r\ntwo\n\n", "one\n\nthree"}
+	for _, input := range valid {
+		if _, err := MaxLines(3)(input); err != nil {
+			t.Errorf("%q: %s", input, err.Error())
+			return
+		}
+	}
+
+	if _, err := MaxLines(3)("1\n2\n3\n4"); !errors.Is(err, ErrMaxLines) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := MaxLines(3)(42); err == nil {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+}
@@ -0,0 +1,302 @@
+package vld
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	ulidPattern  = regexp.MustCompile(PATTERN_ULID)
+	ksuidPattern = regexp.MustCompile(PATTERN_KSUID)
+)
+
+// uuidVersion returns the version and variant nibbles of a UUID string which
+// has already been matched against PATTERN_UUID.
+func uuidVersion(uuid string) (version int, variant byte) {
+	version = strings.IndexByte("0123456789abcdef", uuid[14])
+	variant = uuid[19]
+	return version, variant
+}
+
+// UUIDVersion check if the provided input is a valid UUID string of one of the
+// provided versions. The UUID must use the RFC 9562 (formerly RFC 4122)
+// variant. Input is matched case-insensitively and the returned value is
+// always lower-case.
+func UUIDVersion(versions ...int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_UUID,
+			Message: "Please provide a valid UUID string",
+			Value:   versions,
+		}
+
+		v, err := UUID(input)
+		if err != nil {
+			return nil, issue
+		}
+
+		asString := strings.ToLower(v.(string))
+		version, variant := uuidVersion(asString)
+		if !strings.ContainsRune("89ab", rune(variant)) {
+			return nil, issue
+		}
+
+		if len(versions) != 0 && !slices.Contains(versions, version) {
+			return nil, issue
+		}
+		return asString, nil
+	}
+}
+
+// ULID check if the provided input is a valid ULID string. Input is matched
+// case-insensitively and the returned value is always upper-case.
+func ULID(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_ULID,
+		Message: "Please provide a valid ULID string",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	if !ulidPattern.MatchString(asString) {
+		return nil, issue
+	}
+	return strings.ToUpper(asString), nil
+}
+
+// ksuidMax is the largest value which can be encoded as a 20-byte KSUID. The
+// base62 alphabet is in ASCII order, which allows KSUIDs to be compared as
+// plain strings.
+const ksuidMax = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+
+// KSUID check if the provided input is a valid K-Sortable Unique Identifier.
+func KSUID(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_KSUID,
+		Message: "Please provide a valid KSUID string",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	if !ksuidPattern.MatchString(asString) || asString > ksuidMax {
+		return nil, issue
+	}
+	return asString, nil
+}
+
+// stripIdentifierSeparators removes the spaces and hyphens used for grouping
+// digits in product codes such as ISBNs.
+func stripIdentifierSeparators(input string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(input)
+}
+
+// gtinValid verifies the check digit of a GTIN family barcode number (EAN-8,
+// UPC-A, EAN-13 and ISBN-13).
+func gtinValid(digits string) bool {
+	if !isDigits(digits) {
+		return false
+	}
+
+	sum := 0
+	for i := len(digits) - 2; i >= 0; i-- {
+		weight := 3
+		if (len(digits)-2-i)%2 == 1 {
+			weight = 1
+		}
+		sum += int(digits[i]-'0') * weight
+	}
+
+	check := (10 - sum%10) % 10
+	return check == int(digits[len(digits)-1]-'0')
+}
+
+func isbn10Valid(isbn string) bool {
+	if len(isbn) != 10 || !isDigits(isbn[:9]) {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(isbn[i]-'0') * (10 - i)
+	}
+
+	switch last := isbn[9]; {
+	case last == 'X' || last == 'x':
+		sum += 10
+	case last >= '0' && last <= '9':
+		sum += int(last - '0')
+	default:
+		return false
+	}
+
+	return sum%11 == 0
+}
+
+func isbn13Valid(isbn string) bool {
+	if len(isbn) != 13 {
+		return false
+	}
+
+	if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return false
+	}
+	return gtinValid(isbn)
+}
+
+// ISBN10 check if the provided input is a valid ISBN-10, including its check
+// digit. Hyphens and spaces are stripped from the returned value.
+func ISBN10(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_ISBN,
+		Message: "Please provide a valid ISBN-10",
+		Value:   10,
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	isbn := strings.ToUpper(stripIdentifierSeparators(asString))
+	if !isbn10Valid(isbn) {
+		return nil, issue
+	}
+	return isbn, nil
+}
+
+// ISBN13 check if the provided input is a valid ISBN-13, including its check
+// digit. Hyphens and spaces are stripped from the returned value.
+func ISBN13(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_ISBN,
+		Message: "Please provide a valid ISBN-13",
+		Value:   13,
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	isbn := stripIdentifierSeparators(asString)
+	if !isbn13Valid(isbn) {
+		return nil, issue
+	}
+	return isbn, nil
+}
+
+// ISBN check if the provided input is either a valid ISBN-10 or ISBN-13.
+// Hyphens and spaces are stripped from the returned value.
+func ISBN(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_ISBN,
+		Message: "Please provide a valid ISBN",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	isbn := strings.ToUpper(stripIdentifierSeparators(asString))
+	if !isbn10Valid(isbn) && !isbn13Valid(isbn) {
+		return nil, issue
+	}
+	return isbn, nil
+}
+
+// EAN13 check if the provided input is a valid EAN-13 barcode number,
+// including its check digit.
+func EAN13(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_EAN,
+		Message: "Please provide a valid EAN-13 number",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	ean := stripIdentifierSeparators(asString)
+	if len(ean) != 13 || !gtinValid(ean) {
+		return nil, issue
+	}
+	return ean, nil
+}
+
+// UPC check if the provided input is a valid 12-digit UPC-A barcode number,
+// including its check digit.
+func UPC(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_UPC,
+		Message: "Please provide a valid UPC number",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	upc := stripIdentifierSeparators(asString)
+	if len(upc) != 12 || !gtinValid(upc) {
+		return nil, issue
+	}
+	return upc, nil
+}
+
+// SemVer check if the provided input is a valid semantic version string, e.g.
+// 1.4.0-beta.1+build.5. A leading "v" is accepted. The returned value is a
+// Version.
+func SemVer(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_SEMVER,
+		Message: "Please provide a valid semantic version",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	version, err := ParseVersion(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return version, nil
+}
+
+// SemVerRange check if the provided input is a valid semantic version (see
+// SemVer) which satisfies the provided constraint, e.g. ">=1.2 <2" or
+// "^1.4 || ~2.1.0". See ParseConstraint for the supported syntax.
+func SemVerRange(constraint string) Rule {
+	parsed, errParse := ParseConstraint(constraint)
+
+	return func(input any) (any, error) {
+		if errParse != nil {
+			return nil, ConfigError{Rule: "SemVerRange", Message: errParse.Error()}
+		}
+
+		version, err := SemVer(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if !parsed.Check(version.(Version)) {
+			return nil, Issue{
+				Code:    CODE_SEMVER,
+				Message: fmt.Sprintf("The version must satisfy '%s'", constraint),
+				Value:   constraint,
+			}
+		}
+		return version, nil
+	}
+}
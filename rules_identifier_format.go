@@ -0,0 +1,167 @@
+package vld
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// IdentifierFormat describes the format checked by Slug, Username and
+// Identifier. Values are made of letters and digits, optionally split by
+// separators.
+type IdentifierFormat struct {
+	// Separators lists the characters allowed between letters and digits e.g.
+	// "-" for slugs or "._" for usernames.
+	Separators string
+
+	// LeadingDigit allows the value to start with a digit. By default the
+	// value must start with a letter.
+	LeadingDigit bool
+
+	// EdgeSeparators allows the value to start or end with a separator.
+	EdgeSeparators bool
+
+	// RepeatedSeparators allows separators to follow each other e.g. "a--b".
+	RepeatedSeparators bool
+
+	// Reserved lists the values which aren't allowed e.g. "admin". They are
+	// matched case-insensitively against the normalized value.
+	Reserved []string
+}
+
+var (
+	// DefaultSlugFormat allows lower-case words split by single hyphens e.g.
+	// "hello-world-2".
+	DefaultSlugFormat = IdentifierFormat{Separators: "-", LeadingDigit: true}
+
+	// DefaultUsernameFormat allows words split by single dots, hyphens or
+	// underscores, starting with a letter e.g. "john.doe".
+	DefaultUsernameFormat = IdentifierFormat{Separators: "._-", Reserved: ReservedWords}
+
+	// DefaultIdentifierFormat allows snake_case identifiers starting with a
+	// letter e.g. "user_id".
+	DefaultIdentifierFormat = IdentifierFormat{Separators: "_"}
+)
+
+// ReservedWords are names commonly reserved for the system itself, which
+// shouldn't be used as usernames or in URLs.
+var ReservedWords = []string{
+	"about", "abuse", "account", "admin", "administrator", "api", "app", "auth",
+	"billing", "blog", "dashboard", "dev", "docs", "help", "home", "info",
+	"login", "logout", "mail", "me", "moderator", "news", "null", "owner",
+	"postmaster", "root", "security", "settings", "signin", "signup", "static",
+	"status", "support", "sysadmin", "system", "undefined", "user", "www",
+}
+
+// checkIdentifier checks the provided normalized value against the format.
+// The returned issue has the provided code unless the value is reserved.
+func checkIdentifier(value string, format IdentifierFormat, code string, isChar func(rune) bool) (any, error) {
+	issue := Issue{
+		Code:    code,
+		Message: "Please provide letters and digits only",
+	}
+
+	if value == "" {
+		return nil, issue
+	}
+
+	var previous rune
+	for i, r := range value {
+		separator := strings.ContainsRune(format.Separators, r)
+		switch {
+		case separator && (i == 0 || i+len(string(r)) == len(value)) && !format.EdgeSeparators:
+			issue.Message = "The value cannot start or end with " + string(r)
+			return nil, issue
+		case separator && previous != 0 && strings.ContainsRune(format.Separators, previous) && !format.RepeatedSeparators:
+			issue.Message = "The value cannot contain consecutive separators"
+			return nil, issue
+		case separator:
+		case !isChar(r):
+			if format.Separators != "" {
+				issue.Message = fmt.Sprintf("Please provide letters, digits and %s only", strings.Join(strings.Split(format.Separators, ""), " "))
+			}
+			return nil, issue
+		case i == 0 && unicode.IsDigit(r) && !format.LeadingDigit:
+			issue.Message = "The value must start with a letter"
+			return nil, issue
+		}
+		previous = r
+	}
+
+	for _, word := range format.Reserved {
+		if strings.EqualFold(word, value) {
+			return nil, Issue{
+				Code:    CODE_RESERVED,
+				Message: fmt.Sprintf("'%s' is reserved", value),
+				Value:   value,
+			}
+		}
+	}
+	return value, nil
+}
+
+func isASCIILetterOrDigit(r rune) bool {
+	return r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// Slug check if the provided input is a URL slug in the provided format e.g.
+// Slug(DefaultSlugFormat). Slugs are made of ASCII letters and digits. The
+// input is trimmed and lower-cased before it is checked, and the returned
+// value is the normalized slug.
+func Slug(format IdentifierFormat) Rule {
+	return func(input any) (any, error) {
+		asString, ok := input.(string)
+		if !ok {
+			return nil, Issue{
+				Code:    CODE_SLUG,
+				Message: "Please provide a valid slug",
+			}
+		}
+
+		slug := strings.ToLower(strings.TrimSpace(asString))
+		return checkIdentifier(slug, format, CODE_SLUG, isASCIILetterOrDigit)
+	}
+}
+
+// Username check if the provided input is a username in the provided format
+// e.g. Username(DefaultUsernameFormat). Usernames are made of letters and
+// digits of any script, see NoMixedScripts to also prevent look-alike names.
+// The input is trimmed, normalized to Unicode NFKC and lower-cased before it
+// is checked, which makes e.g. "ＪｏｈｎDoe" and "johndoe" the same username. The
+// returned value is the normalized username.
+func Username(format IdentifierFormat) Rule {
+	return func(input any) (any, error) {
+		asString, ok := input.(string)
+		if !ok {
+			return nil, Issue{
+				Code:    CODE_USERNAME,
+				Message: "Please provide a valid username",
+			}
+		}
+
+		username := strings.ToLower(norm.NFKC.String(strings.TrimSpace(asString)))
+		return checkIdentifier(username, format, CODE_USERNAME, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+		})
+	}
+}
+
+// Identifier check if the provided input is an identifier, such as a field or
+// a key name, in the provided format e.g. Identifier(DefaultIdentifierFormat).
+// Identifiers are made of ASCII letters and digits, and keep their case. The
+// input is trimmed before it is checked.
+func Identifier(format IdentifierFormat) Rule {
+	return func(input any) (any, error) {
+		asString, ok := input.(string)
+		if !ok {
+			return nil, Issue{
+				Code:    CODE_IDENTIFIER,
+				Message: "Please provide a valid identifier",
+			}
+		}
+
+		return checkIdentifier(strings.TrimSpace(asString), format, CODE_IDENTIFIER, isASCIILetterOrDigit)
+	}
+}
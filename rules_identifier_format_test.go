@@ -0,0 +1,119 @@
+package vld
+
+import (
+	"errors"
+	"testing"
+)
+
+/**
+ * Rule: Slug
+ *
+ */
+func TestSlug(t *testing.T) {
+	rule := Slug(DefaultSlugFormat)
+	testCases := map[string]string{"hello-world": "hello-world", " Hello-World-2 ": "hello-world-2", "2024-recap": "2024-recap"}
+	for input, expected := range testCases {
+		v, err := rule(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if v != expected {
+			t.Errorf("unexpected slug %v for %q", v, input)
+			return
+		}
+	}
+
+	for _, input := range []string{"", "hello_world", "-hello", "hello-", "hello--world", "héllo", "hello world"} {
+		if _, err := rule(input); !errors.Is(err, ErrSlug) {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+
+	if _, err := rule(42); err == nil {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+}
+
+func TestSlugCustomFormat(t *testing.T) {
+	rule := Slug(IdentifierFormat{Separators: "-_", RepeatedSeparators: true, Reserved: []string{"new"}})
+	if _, err := rule("hello__world-"); err == nil {
+		t.Error("edge separators must not be allowed")
+		return
+	}
+
+	if _, err := rule("hello__world"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := rule("2024"); err == nil {
+		t.Error("leading digits must not be allowed")
+		return
+	}
+
+	if _, err := rule("NEW"); !errors.Is(err, ErrReserved) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: Username
+ *
+ */
+func TestUsername(t *testing.T) {
+	rule := Username(DefaultUsernameFormat)
+	testCases := map[string]string{"john.doe": "john.doe", "ＪｏｈｎDoe": "johndoe", "José_42": "josé_42"}
+	for input, expected := range testCases {
+		v, err := rule(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if v != expected {
+			t.Errorf("unexpected username %v for %q", v, input)
+			return
+		}
+	}
+
+	for _, input := range []string{"", "42john", ".john", "john..doe", "john doe", "john@doe"} {
+		if _, err := rule(input); !errors.Is(err, ErrUsername) {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+
+	for _, input := range []string{"Admin", " ROOT ", "ａｄｍｉｎ"} {
+		if _, err := rule(input); !errors.Is(err, ErrReserved) {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: Identifier
+ *
+ */
+func TestIdentifier(t *testing.T) {
+	rule := Identifier(DefaultIdentifierFormat)
+	for _, input := range []string{"user_id", "UserID", "a1"} {
+		v, err := rule(input)
+		if err != nil || v != input {
+			t.Errorf("%q: %s", input, errValidFailed)
+			return
+		}
+	}
+
+	for _, input := range []string{"_private", "user__id", "1st", "user-id", "naïve"} {
+		if _, err := rule(input); !errors.Is(err, ErrIdentifier) {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package vld
+
+import (
+	"testing"
+)
+
+/**
+ * Rule: UUID
+ *
+ */
+func TestUUIDMixedCaseInput(t *testing.T) {
+	v, err := UUID("550E8400-e29b-41d4-A716-446655440000")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if v != "550e8400-e29b-41d4-a716-446655440000" {
+		t.Error(errInvalidReturnType)
+		return
+	}
+}
+
+/**
+ * Rule: UUIDVersion
+ *
+ */
+func TestUUIDVersionValidInput(t *testing.T) {
+	testCases := []struct {
+		input    string
+		versions []int
+	}{
+		{input: "bb4b84e0-dd43-11ee-abc5-d31f3e7a8b9a", versions: []int{1}},
+		{input: "550e8400-e29b-41d4-a716-446655440000", versions: []int{4}},
+		{input: "342eef96-0452-5b99-ac4d-612f79cb102c", versions: []int{4, 5}},
+		{input: "018E5A5C-8F5A-7B3C-9D2E-1F2A3B4C5D6E", versions: []int{7}},
+		{input: "550e8400-e29b-41d4-a716-446655440000", versions: nil},
+	}
+
+	for _, testCase := range testCases {
+		if _, err := UUIDVersion(testCase.versions...)(testCase.input); err != nil {
+			t.Errorf(errValidFailed, testCase.input)
+			return
+		}
+	}
+}
+
+func TestUUIDVersionInvalidInput(t *testing.T) {
+	testCases := []struct {
+		input    any
+		versions []int
+	}{
+		{input: "550e8400-e29b-41d4-a716-446655440000", versions: []int{7}},
+		{input: "550e8400-e29b-41d4-c716-446655440000", versions: nil}, // microsoft variant
+		{input: "550e8400-e29b-41d4-a716-4466554400", versions: nil},
+		{input: 10, versions: nil},
+	}
+
+	for _, testCase := range testCases {
+		if _, err := UUIDVersion(testCase.versions...)(testCase.input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: ULID
+ *
+ */
+func TestULID(t *testing.T) {
+	v, err := ULID("01arz3ndektsv4rrffq69g5fav")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if v != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	inputs := []any{
+		"81ARZ3NDEKTSV4RRFFQ69G5FAV", // overflows 48-bit timestamp
+		"01ARZ3NDEKTSV4RRFFQ69G5FAI", // I is not part of the alphabet
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",
+		true,
+	}
+
+	for _, input := range inputs {
+		if _, err := ULID(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: KSUID
+ *
+ */
+func TestKSUID(t *testing.T) {
+	for _, input := range []string{"0ujtsYcgvSTl8PAuAdqWYSMnLOv", ksuidMax} {
+		if _, err := KSUID(input); err != nil {
+			t.Errorf(errValidFailed, input)
+			return
+		}
+	}
+
+	for _, input := range []any{"aWgEPTl1tmebfsQzFP4bxwgy80W", "0ujtsYcgvSTl8PAuAdqWYSMnLO", "0ujtsYcgvSTl8PAuAdqWYSMnLO-", 1} {
+		if _, err := KSUID(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: ISBN
+ *
+ */
+func TestISBN(t *testing.T) {
+	testCases := []struct {
+		input    string
+		rule     Rule
+		expected string
+	}{
+		{input: "0-306-40615-2", rule: ISBN10, expected: "0306406152"},
+		{input: "0-8044-2957-x", rule: ISBN10, expected: "080442957X"},
+		{input: "978-0-306-40615-7", rule: ISBN13, expected: "9780306406157"},
+		{input: "978 0 306 40615 7", rule: ISBN, expected: "9780306406157"},
+		{input: "0306406152", rule: ISBN, expected: "0306406152"},
+	}
+
+	for _, testCase := range testCases {
+		v, err := testCase.rule(testCase.input)
+		if err != nil {
+			t.Errorf(errValidFailed, testCase.input)
+			return
+		}
+
+		if v != testCase.expected {
+			t.Error(errInvalidReturnType)
+			return
+		}
+	}
+
+	invalid := []struct {
+		input any
+		rule  Rule
+	}{
+		{input: "0-306-40615-3", rule: ISBN10},
+		{input: "978-0-306-40615-7", rule: ISBN10},
+		{input: "978-0-306-40615-8", rule: ISBN13},
+		{input: "4006381333931", rule: ISBN13}, // valid EAN, not a book
+		{input: "030640615", rule: ISBN},
+		{input: 306406152, rule: ISBN},
+	}
+
+	for _, testCase := range invalid {
+		if _, err := testCase.rule(testCase.input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: EAN13, UPC
+ *
+ */
+func TestEAN13AndUPC(t *testing.T) {
+	if _, err := EAN13("4006381333931"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := UPC("036000291452"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := EAN13("4006381333932"); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := UPC("036000291453"); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := UPC("4006381333931"); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: SemVer
+ *
+ */
+func TestSemVer(t *testing.T) {
+	v, err := SemVer("v1.4.0-beta.1+build.5")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	version, ok := v.(Version)
+	if !ok || version.Major != 1 || version.Minor != 4 || len(version.Prerelease) != 2 {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	for _, input := range []any{"1.4", "01.2.3", "1.2.3-", "1.2.3+", 1.2} {
+		if _, err := SemVer(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: SemVerRange
+ *
+ */
+func TestSemVerRange(t *testing.T) {
+	v := SemVerRange(">=1.2 <2")
+	for _, input := range []string{"1.2.0", "1.9.9", "1.10.0-rc.1"} {
+		if _, err := v(input); err != nil {
+			t.Errorf(errValidFailed, input)
+			return
+		}
+	}
+
+	for _, input := range []string{"1.1.9", "2.0.0", "1.2.0-alpha"} {
+		if _, err := v(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+
+	if _, err := SemVerRange(">=>1")("1.0.0"); err == nil {
+		t.Error("invalid constraint returned as valid")
+		return
+	}
+}
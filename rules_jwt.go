@@ -0,0 +1,249 @@
+package vld
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Signing algorithms of JWTs (RFC 7518 and RFC 8037) supported by the JWT
+// rule. The "none" algorithm is never accepted.
+const (
+	JWT_HS256 = "HS256"
+	JWT_HS384 = "HS384"
+	JWT_HS512 = "HS512"
+	JWT_EDDSA = "EdDSA"
+)
+
+var jwtHashes = map[string]func() hash.Hash{
+	JWT_HS256: sha256.New,
+	JWT_HS384: sha512.New384,
+	JWT_HS512: sha512.New,
+}
+
+// JWTOptions configures the JWT rule.
+type JWTOptions struct {
+	// Algorithms lists the allowed signing algorithms, e.g. JWT_HS256. At
+	// least one algorithm is required.
+	Algorithms []string
+
+	// Key verifies the signature of the token: a []byte secret for the HS*
+	// algorithms, or an ed25519.PublicKey for JWT_EDDSA. When it's nil the
+	// signature isn't verified, which is only safe when the token is verified
+	// elsewhere.
+	Key any
+
+	// Issuer and Audience, when not empty, are required to match the "iss"
+	// claim and to be one of the "aud" claim respectively.
+	Issuer   string
+	Audience string
+
+	// RequiredClaims lists the claims which must be present e.g. "sub".
+	RequiredClaims []string
+
+	// Leeway is the clock skew tolerated when checking the "exp", "nbf" and
+	// "iat" claims.
+	Leeway time.Duration
+
+	// Clock returns the current time, it defaults to time.Now.
+	Clock Clock
+}
+
+// config returns a ConfigError when the options are invalid.
+func (options JWTOptions) config() error {
+	if len(options.Algorithms) == 0 {
+		return ConfigError{Rule: "JWT", Message: "at least one algorithm is required"}
+	}
+
+	for _, algorithm := range options.Algorithms {
+		var valid bool
+		switch algorithm {
+		case JWT_HS256, JWT_HS384, JWT_HS512:
+			_, valid = options.Key.([]byte)
+		case JWT_EDDSA:
+			key, ok := options.Key.(ed25519.PublicKey)
+			valid = ok && len(key) == ed25519.PublicKeySize
+		default:
+			return ConfigError{Rule: "JWT", Message: fmt.Sprintf("unsupported algorithm '%s'", algorithm)}
+		}
+
+		if options.Key != nil && !valid {
+			return ConfigError{Rule: "JWT", Message: fmt.Sprintf("invalid key %T for algorithm '%s'", options.Key, algorithm)}
+		}
+	}
+	return nil
+}
+
+// verify reports whether the signature of the signed part of a token is valid
+// for the provided algorithm.
+func (options JWTOptions) verify(algorithm string, signed string, signature []byte) bool {
+	if algorithm == JWT_EDDSA {
+		return ed25519.Verify(options.Key.(ed25519.PublicKey), []byte(signed), signature)
+	}
+
+	mac := hmac.New(jwtHashes[algorithm], options.Key.([]byte))
+	mac.Write([]byte(signed))
+	return hmac.Equal(mac.Sum(nil), signature)
+}
+
+// decodeJWTSegment decodes a base64url-encoded JSON object of a token.
+func decodeJWTSegment(segment string) (map[string]any, bool) {
+	content, err := base64.RawURLEncoding.Strict().DecodeString(segment)
+	if err != nil {
+		return nil, false
+	}
+
+	var decoded map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if err := decoder.Decode(&decoded); err != nil || decoded == nil || decoder.More() {
+		return nil, false
+	}
+	return decoded, true
+}
+
+// jwtClaimIssue returns an issue about a claim of a token.
+func jwtClaimIssue(code string, claim string, message string) Issue {
+	return Issue{
+		Code:    code,
+		Message: message,
+		Value:   claim,
+		Path:    keyPath(claim),
+	}
+}
+
+// checkJWTClaims checks the registered claims of a token.
+func checkJWTClaims(claims map[string]any, options JWTOptions) error {
+	for _, claim := range options.RequiredClaims {
+		if _, ok := claims[claim]; !ok {
+			return jwtClaimIssue(CODE_REQUIRED_KEY, claim, "This field is required")
+		}
+	}
+
+	now := options.Clock.now()
+	for _, claim := range []string{"exp", "nbf", "iat"} {
+		value, ok := claims[claim]
+		if !ok {
+			continue
+		}
+
+		seconds, ok := value.(float64)
+		if !ok {
+			return jwtClaimIssue(CODE_JWT, claim, "The claim must be a numeric date")
+		}
+
+		at := time.UnixMilli(int64(seconds * 1000))
+		switch {
+		case claim == "exp" && !now.Before(at.Add(options.Leeway)):
+			return jwtClaimIssue(CODE_JWT_EXPIRED, claim, "The token has expired")
+		case claim == "nbf" && now.Add(options.Leeway).Before(at):
+			return jwtClaimIssue(CODE_JWT_NOT_BEFORE, claim, "The token is not valid yet")
+		case claim == "iat" && now.Add(options.Leeway).Before(at):
+			return jwtClaimIssue(CODE_JWT_ISSUED_AT, claim, "The token cannot be issued in the future")
+		}
+	}
+
+	if options.Issuer != "" && claims["iss"] != options.Issuer {
+		issue := jwtClaimIssue(CODE_JWT_ISSUER, "iss", fmt.Sprintf("The token must be issued by %s", options.Issuer))
+		issue.Value = options.Issuer
+		return issue
+	}
+
+	if options.Audience != "" {
+		var audiences []any
+		switch t := claims["aud"].(type) {
+		case string:
+			audiences = []any{t}
+		case []any:
+			audiences = t
+		}
+
+		if !slices.Contains(audiences, any(options.Audience)) {
+			issue := jwtClaimIssue(CODE_JWT_AUDIENCE, "aud", fmt.Sprintf("The token must be intended for %s", options.Audience))
+			issue.Value = options.Audience
+			return issue
+		}
+	}
+	return nil
+}
+
+// JWT check if the provided input is a JSON Web Token in compact form, e.g.
+// "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.<signature>", signed with one of the
+// allowed algorithms. The signature is verified when a key is provided, then
+// the "exp", "nbf" and "iat" claims are checked against the clock, as well as
+// the required claims, the issuer and the audience. Claim issues are reported
+// at the path of the claim e.g. ".exp". The returned value is the decoded
+// claims, as map[string]any.
+func JWT(options JWTOptions) Rule {
+	errConfig := options.config()
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		issue := Issue{
+			Code:    CODE_JWT,
+			Message: "Please provide a valid token",
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		segments := strings.Split(asString, ".")
+		if len(segments) != 3 {
+			return nil, issue
+		}
+
+		header, ok := decodeJWTSegment(segments[0])
+		if !ok {
+			return nil, issue
+		}
+
+		claims, ok := decodeJWTSegment(segments[1])
+		if !ok {
+			return nil, issue
+		}
+
+		signature, err := base64.RawURLEncoding.Strict().DecodeString(segments[2])
+		if err != nil {
+			return nil, issue
+		}
+
+		// extensions listed as critical must be understood, and none are.
+		if _, ok := header["crit"]; ok {
+			return nil, issue
+		}
+
+		algorithm, _ := header["alg"].(string)
+		if !slices.Contains(options.Algorithms, algorithm) {
+			return nil, Issue{
+				Code:    CODE_JWT_ALGORITHM,
+				Message: fmt.Sprintf("The token must be signed using %s", strings.Join(options.Algorithms, ", ")),
+				Value:   options.Algorithms,
+			}
+		}
+
+		if options.Key != nil && !options.verify(algorithm, segments[0]+"."+segments[1], signature) {
+			return nil, Issue{
+				Code:    CODE_JWT_SIGNATURE,
+				Message: "The token signature is invalid",
+			}
+		}
+
+		if err := checkJWTClaims(claims, options); err != nil {
+			return nil, err
+		}
+		return claims, nil
+	}
+}
@@ -0,0 +1,167 @@
+package vld
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+var jwtSecret = []byte("secret")
+
+// signJWT returns a token with the provided header and claims, signed with
+// HS256 or Ed25519 depending on the key.
+func signJWT(header map[string]any, claims map[string]any, key any) string {
+	encode := func(value any) string {
+		encoded, _ := json.Marshal(value)
+		return base64.RawURLEncoding.EncodeToString(encoded)
+	}
+
+	signed := encode(header) + "." + encode(claims)
+	var signature []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(k, []byte(signed))
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+/**
+ * Rule: JWT
+ *
+ */
+func TestJWT(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	options := JWTOptions{
+		Algorithms:     []string{JWT_HS256},
+		Key:            jwtSecret,
+		Issuer:         "https://auth.example.com",
+		Audience:       "api",
+		RequiredClaims: []string{"sub"},
+		Leeway:         time.Minute,
+		Clock:          fixedClock(now),
+	}
+	header := map[string]any{"alg": "HS256", "typ": "JWT"}
+	claims := map[string]any{
+		"sub": "42",
+		"iss": "https://auth.example.com",
+		"aud": []string{"web", "api"},
+		"iat": now.Add(-time.Hour).Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+
+	v, err := JWT(options)(signJWT(header, claims, jwtSecret))
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	decoded, ok := v.(map[string]any)
+	if !ok || decoded["sub"] != "42" {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	with := func(claim string, value any) map[string]any {
+		changed := make(map[string]any, len(claims))
+		for key, value := range claims {
+			changed[key] = value
+		}
+
+		if value == nil {
+			delete(changed, claim)
+		} else {
+			changed[claim] = value
+		}
+		return changed
+	}
+
+	testCases := []struct {
+		token string
+		err   error
+		path  string
+	}{
+		{"not a token", ErrJWT, ""},
+		{signJWT(header, claims, jwtSecret) + "!", ErrJWT, ""},
+		{signJWT(header, claims, []byte("other")), ErrJWTSignature, ""},
+		{signJWT(map[string]any{"alg": "none"}, claims, nil), ErrJWTAlgorithm, ""},
+		{signJWT(map[string]any{"alg": "HS256", "crit": []string{"b64"}}, claims, jwtSecret), ErrJWT, ""},
+		{signJWT(header, with("sub", nil), jwtSecret), ErrRequiredKey, ".sub"},
+		{signJWT(header, with("exp", now.Add(-2*time.Minute).Unix()), jwtSecret), ErrJWTExpired, ".exp"},
+		{signJWT(header, with("nbf", now.Add(2*time.Minute).Unix()), jwtSecret), ErrJWTNotBefore, ".nbf"},
+		{signJWT(header, with("iat", now.Add(2*time.Minute).Unix()), jwtSecret), ErrJWTIssuedAt, ".iat"},
+		{signJWT(header, with("exp", "tomorrow"), jwtSecret), ErrJWT, ".exp"},
+		{signJWT(header, with("iss", "https://evil.example.com"), jwtSecret), ErrJWTIssuer, ".iss"},
+		{signJWT(header, with("aud", "web"), jwtSecret), ErrJWTAudience, ".aud"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := JWT(options)(testCase.token)
+		if !errors.Is(err, testCase.err) {
+			t.Errorf("%s: expected %v, got %v", testCase.token, testCase.err, err)
+			return
+		}
+
+		if issues := Issues(err); issues[0].Path != testCase.path {
+			t.Errorf("unexpected path %q, expected %q", issues[0].Path, testCase.path)
+			return
+		}
+	}
+
+	// within the leeway
+	if _, err := JWT(options)(signJWT(header, with("exp", now.Add(-30*time.Second).Unix()), jwtSecret)); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+}
+
+func TestJWTEdDSA(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(nil)
+	_, otherPrivate, _ := ed25519.GenerateKey(nil)
+	rule := JWT(JWTOptions{Algorithms: []string{JWT_EDDSA}, Key: public})
+	header := map[string]any{"alg": "EdDSA"}
+
+	if _, err := rule(signJWT(header, map[string]any{"sub": "42"}, private)); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := rule(signJWT(header, map[string]any{"sub": "42"}, otherPrivate)); !errors.Is(err, ErrJWTSignature) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+func TestJWTWithoutKey(t *testing.T) {
+	rule := JWT(JWTOptions{Algorithms: []string{JWT_HS256}})
+	if _, err := rule(signJWT(map[string]any{"alg": "HS256"}, map[string]any{"sub": "42"}, []byte("any"))); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+}
+
+func TestJWTConfig(t *testing.T) {
+	public, _, _ := ed25519.GenerateKey(nil)
+	invalid := []JWTOptions{
+		{},
+		{Algorithms: []string{"none"}},
+		{Algorithms: []string{JWT_HS256}, Key: public},
+		{Algorithms: []string{JWT_EDDSA}, Key: jwtSecret},
+	}
+
+	for _, options := range invalid {
+		var errConfig ConfigError
+		if _, err := JWT(options)("token"); !errors.As(err, &errConfig) {
+			t.Errorf("expected a config error for %v", options)
+			return
+		}
+	}
+}
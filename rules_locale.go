@@ -0,0 +1,321 @@
+package vld
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// countryAlpha2 maps ISO 3166-1 alpha-3 country codes to their alpha-2
+// counterparts.
+var countryAlpha2 = func() map[string]string {
+	inverted := make(map[string]string, len(countryAlpha3))
+	for alpha2, alpha3 := range countryAlpha3 {
+		inverted[alpha3] = alpha2
+	}
+	return inverted
+}()
+
+// CountryAlpha2 check if the provided input is a valid ISO 3166-1 alpha-2
+// country code, e.g. US. Input is matched case-insensitively and the returned
+// value is always upper-case.
+func CountryAlpha2(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_COUNTRY,
+		Message: "Please provide a valid two letter country code",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	code := strings.ToUpper(asString)
+	if _, ok := countryAlpha3[code]; !ok {
+		return nil, issue
+	}
+	return code, nil
+}
+
+// CountryAlpha3 check if the provided input is a valid ISO 3166-1 alpha-3
+// country code, e.g. USA. Input is matched case-insensitively and the returned
+// value is always upper-case.
+func CountryAlpha3(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_COUNTRY,
+		Message: "Please provide a valid three letter country code",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	code := strings.ToUpper(asString)
+	if _, ok := countryAlpha2[code]; !ok {
+		return nil, issue
+	}
+	return code, nil
+}
+
+// Currency check if the provided input is a valid ISO 4217 currency code, e.g.
+// EUR. Input is matched case-insensitively and the returned value is always
+// upper-case.
+func Currency(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_CURRENCY,
+		Message: "Please provide a valid currency code",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	code := strings.ToUpper(asString)
+	if _, ok := currencyMinorUnits[code]; !ok {
+		return nil, issue
+	}
+	return code, nil
+}
+
+// CurrencyMinorUnits returns the number of digits after the decimal separator
+// used by the provided ISO 4217 currency, e.g. 2 for USD and 0 for JPY.
+func CurrencyMinorUnits(code string) (int, bool) {
+	digits, ok := currencyMinorUnits[strings.ToUpper(code)]
+	return digits, ok
+}
+
+func isAlpha(input string) bool {
+	for _, r := range input {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return input != ""
+}
+
+func isAlphanumeric(input string) bool {
+	for _, r := range input {
+		if r > unicode.MaxASCII || (!unicode.IsLetter(r) && !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return input != ""
+}
+
+// parseLanguageTag checks the structure of a BCP 47 language tag (RFC 5646)
+// and returns it with the canonical casing of each subtag. Two letter primary
+// languages and alphabetic regions are checked against the bundled ISO tables.
+func parseLanguageTag(tag string) (string, bool) {
+	subtags := strings.Split(tag, "-")
+	for _, subtag := range subtags {
+		if len(subtag) == 0 || len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return "", false
+		}
+	}
+
+	// private use tags, e.g. x-whatever.
+	if strings.EqualFold(subtags[0], "x") {
+		return strings.ToLower(tag), len(subtags) > 1
+	}
+
+	language := strings.ToLower(subtags[0])
+	if !isAlpha(language) || len(language) == 1 || len(language) == 4 {
+		return "", false
+	}
+
+	if len(language) == 2 && !iso639Alpha2[language] {
+		return "", false
+	}
+
+	canonical := []string{language}
+	rest := subtags[1:]
+
+	// up to three extended language subtags may follow a 2-3 letter language.
+	for extlangs := 0; len(language) <= 3 && extlangs < 3 && len(rest) != 0; extlangs++ {
+		if len(rest[0]) != 3 || !isAlpha(rest[0]) {
+			break
+		}
+		canonical = append(canonical, strings.ToLower(rest[0]))
+		rest = rest[1:]
+	}
+
+	// script, e.g. Latn.
+	if len(rest) != 0 && len(rest[0]) == 4 && isAlpha(rest[0]) {
+		canonical = append(canonical, strings.ToUpper(rest[0][:1])+strings.ToLower(rest[0][1:]))
+		rest = rest[1:]
+	}
+
+	// region, e.g. US or 419.
+	if len(rest) != 0 && len(rest[0]) == 2 && isAlpha(rest[0]) {
+		region := strings.ToUpper(rest[0])
+		if _, ok := countryAlpha3[region]; !ok {
+			return "", false
+		}
+		canonical = append(canonical, region)
+		rest = rest[1:]
+	} else if len(rest) != 0 && len(rest[0]) == 3 && isDigits(rest[0]) {
+		canonical = append(canonical, rest[0])
+		rest = rest[1:]
+	}
+
+	// variants, e.g. 1901 or rozaj.
+	var variants []string
+	for len(rest) != 0 {
+		variant := strings.ToLower(rest[0])
+		isVariant := len(variant) >= 5 || (len(variant) == 4 && variant[0] >= '0' && variant[0] <= '9')
+		if !isVariant {
+			break
+		}
+
+		if slices.Contains(variants, variant) {
+			return "", false
+		}
+		variants = append(variants, variant)
+		canonical = append(canonical, variant)
+		rest = rest[1:]
+	}
+
+	// extensions and private use, e.g. u-ca-buddhist or x-private.
+	var singletons []string
+	for len(rest) != 0 {
+		singleton := strings.ToLower(rest[0])
+		if len(singleton) != 1 || len(rest) < 2 {
+			return "", false
+		}
+
+		if singleton == "x" {
+			for _, subtag := range rest {
+				canonical = append(canonical, strings.ToLower(subtag))
+			}
+			break
+		}
+
+		if slices.Contains(singletons, singleton) {
+			return "", false
+		}
+		singletons = append(singletons, singleton)
+		canonical = append(canonical, singleton)
+		rest = rest[1:]
+
+		extensions := 0
+		for len(rest) != 0 && len(rest[0]) >= 2 {
+			canonical = append(canonical, strings.ToLower(rest[0]))
+			rest = rest[1:]
+			extensions++
+		}
+
+		if extensions == 0 {
+			return "", false
+		}
+	}
+
+	return strings.Join(canonical, "-"), true
+}
+
+// LanguageTag check if the provided input is a well-formed BCP 47 language
+// tag, e.g. en-US or zh-Hant-TW. The returned value uses the canonical casing
+// of each subtag.
+func LanguageTag(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_LANGUAGE,
+		Message: "Please provide a valid language tag",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	tag, ok := parseLanguageTag(asString)
+	if !ok {
+		return nil, issue
+	}
+	return tag, nil
+}
+
+// Timezone check if the provided input is a valid IANA timezone name, e.g.
+// Europe/Berlin. The returned value is the loaded *time.Location. Timezone
+// data is read from the system, programs running where it isn't available
+// should import time/tzdata.
+func Timezone(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_TIMEZONE,
+		Message: "Please provide a valid timezone",
+	}
+
+	asString, ok := input.(string)
+	if !ok || asString == "" || strings.EqualFold(asString, "local") {
+		return nil, issue
+	}
+
+	location, err := time.LoadLocation(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return location, nil
+}
+
+// Subdivision check if the provided input is a valid ISO 3166-2 subdivision of
+// the provided country, e.g. "CA" or "US-CA" for California. When country is
+// empty, the input must include the country prefix. The returned value is the
+// full upper-case subdivision code, e.g. US-CA. Only the countries listed in
+// SubdivisionCountries are supported, all other countries are reported as
+// issues.
+func Subdivision(country string) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_SUBDIVISION,
+			Message: "Please provide a valid state or region",
+			Value:   country,
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		code := strings.ToUpper(strings.TrimSpace(asString))
+		expectedCountry := strings.ToUpper(country)
+		prefix, subdivision, found := strings.Cut(code, "-")
+		if found {
+			if expectedCountry != "" && prefix != expectedCountry {
+				return nil, issue
+			}
+			expectedCountry = prefix
+			code = subdivision
+		}
+
+		if expectedCountry == "" {
+			return nil, issue
+		}
+
+		codes, ok := subdivisions[expectedCountry]
+		if !ok {
+			return nil, Issue{
+				Code:    CODE_SUBDIVISION,
+				Message: fmt.Sprintf("Subdivisions of country '%s' are not supported", expectedCountry),
+				Value:   expectedCountry,
+			}
+		}
+
+		if !slices.Contains(codes, code) {
+			return nil, issue
+		}
+		return expectedCountry + "-" + code, nil
+	}
+}
+
+// SubdivisionCountries returns the ISO 3166-1 alpha-2 codes of the countries
+// whose subdivisions are bundled and can be checked with Subdivision.
+func SubdivisionCountries() []string {
+	countries := make([]string, 0, len(subdivisions))
+	for country := range subdivisions {
+		countries = append(countries, country)
+	}
+	slices.Sort(countries)
+	return countries
+}
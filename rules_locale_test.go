@@ -0,0 +1,212 @@
+package vld
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+/**
+ * Rule: CountryAlpha2, CountryAlpha3
+ *
+ */
+func TestCountryCodes(t *testing.T) {
+	v, err := CountryAlpha2("pk")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if v != "PK" {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	if _, err := CountryAlpha3("DEU"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	invalid := []struct {
+		input any
+		rule  Rule
+	}{
+		{input: "XX", rule: CountryAlpha2},
+		{input: "DEU", rule: CountryAlpha2},
+		{input: "DE", rule: CountryAlpha3},
+		{input: "UKR1", rule: CountryAlpha3},
+		{input: 10, rule: CountryAlpha2},
+	}
+
+	for _, testCase := range invalid {
+		if _, err := testCase.rule(testCase.input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: Currency
+ *
+ */
+func TestCurrency(t *testing.T) {
+	v, err := Currency("usd")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if v != "USD" {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	for _, input := range []any{"ABC", "US", 840} {
+		if _, err := Currency(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+
+	expected := map[string]int{"USD": 2, "JPY": 0, "KWD": 3, "CLF": 4}
+	for code, digits := range expected {
+		if actual, ok := CurrencyMinorUnits(code); !ok || actual != digits {
+			t.Errorf("unexpected minor units for %s: %d", code, actual)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: LanguageTag
+ *
+ */
+func TestLanguageTagValidInput(t *testing.T) {
+	testCases := map[string]string{
+		"en":                 "en",
+		"en-us":              "en-US",
+		"ZH-hant-tw":         "zh-Hant-TW",
+		"es-419":             "es-419",
+		"sl-rozaj-biske":     "sl-rozaj-biske",
+		"de-CH-1901":         "de-CH-1901",
+		"zh-yue-HK":          "zh-yue-HK",
+		"en-US-u-ca-gregory": "en-US-u-ca-gregory",
+		"en-x-private":       "en-x-private",
+		"x-whatever":         "x-whatever",
+		"haw":                "haw",
+	}
+
+	for input, expected := range testCases {
+		v, err := LanguageTag(input)
+		if err != nil {
+			t.Errorf(errValidFailed, input)
+			return
+		}
+
+		if v != expected {
+			t.Errorf("unexpected canonical tag %s for %s", v, input)
+			return
+		}
+	}
+}
+
+func TestLanguageTagInvalidInput(t *testing.T) {
+	inputs := []any{
+		"",
+		"e",
+		"qq",      // not an ISO 639-1 language
+		"en-XY",   // not an ISO 3166-1 region
+		"en--US",  // empty subtag
+		"en-US-u", // extension without subtags
+		"de-1901-1901",
+		"en-toolongsubtag",
+		"en_US",
+		42,
+	}
+
+	for _, input := range inputs {
+		if _, err := LanguageTag(input); err == nil {
+			t.Errorf("invalid input returned as valid: %v", input)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: Timezone
+ *
+ */
+func TestTimezone(t *testing.T) {
+	v, err := Timezone("Asia/Karachi")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, ok := v.(*time.Location); !ok {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	for _, input := range []any{"Mars/Olympus_Mons", "", "Local", 5} {
+		if _, err := Timezone(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: Subdivision
+ *
+ */
+func TestSubdivision(t *testing.T) {
+	testCases := []struct {
+		country  string
+		input    string
+		expected string
+	}{
+		{country: "US", input: "ca", expected: "US-CA"},
+		{country: "US", input: "US-NY", expected: "US-NY"},
+		{country: "", input: "ca-qc", expected: "CA-QC"},
+		{country: "in", input: "DL", expected: "IN-DL"},
+	}
+
+	for _, testCase := range testCases {
+		v, err := Subdivision(testCase.country)(testCase.input)
+		if err != nil {
+			t.Errorf(errValidFailed, testCase.input)
+			return
+		}
+
+		if v != testCase.expected {
+			t.Error(errInvalidReturnType)
+			return
+		}
+	}
+
+	invalid := []struct {
+		country string
+		input   any
+	}{
+		{country: "US", input: "XX"},
+		{country: "US", input: "CA-ON"},
+		{country: "", input: "CA"},
+		{country: "ZZ", input: "AB"},
+		{country: "US", input: 10},
+	}
+
+	for _, testCase := range invalid {
+		if _, err := Subdivision(testCase.country)(testCase.input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+
+	_, err := Subdivision("FR")("75")
+	if issue, ok := err.(Issue); !ok || issue.Value != "FR" {
+		t.Error("unsupported country must be reported")
+		return
+	}
+}
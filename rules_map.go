@@ -0,0 +1,294 @@
+package vld
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Map rules work on maps with string keys of any value type, such as
+// map[string]any payloads or map[string]string label sets. Issues about a
+// single entry are reported with the key as their path, e.g. "labels.env".
+
+const (
+	// UNKNOWN_KEYS_STRICT reports keys which aren't listed as an issue.
+	UNKNOWN_KEYS_STRICT = "strict"
+
+	// UNKNOWN_KEYS_STRIP removes keys which aren't listed from the output.
+	UNKNOWN_KEYS_STRIP = "strip"
+
+	// UNKNOWN_KEYS_PASSTHROUGH keeps keys which aren't listed as they are.
+	UNKNOWN_KEYS_PASSTHROUGH = "passthrough"
+)
+
+// MapKey describes a key of the map validated by the Map rule.
+type MapKey struct {
+	Key      string
+	Required bool
+	Rules    []Rule
+}
+
+// RequiredKey describes a key which must be present in the map, and the rules
+// its value must pass.
+func RequiredKey(key string, rules ...Rule) MapKey {
+	return MapKey{Key: key, Required: true, Rules: rules}
+}
+
+// OptionalKey describes a key which may be left out of the map, and the rules
+// its value must pass when it's present.
+func OptionalKey(key string, rules ...Rule) MapKey {
+	return MapKey{Key: key, Rules: rules}
+}
+
+// stringMap returns the provided input as a reflect.Value when it is a map
+// with string keys.
+func stringMap(input any) (reflect.Value, bool) {
+	value := reflect.ValueOf(input)
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}, false
+	}
+	return value, true
+}
+
+func mapIssue(code string) Issue {
+	return Issue{
+		Code:    code,
+		Message: "Please provide an object",
+	}
+}
+
+// keyPath returns the path of the entry with the provided key.
+func keyPath(key string) string {
+	return "." + key
+}
+
+// sortedKeys returns the keys of the provided map in order, which keeps the
+// order of the reported issues stable.
+func sortedKeys(value reflect.Value) []string {
+	keys := make([]string, 0, value.Len())
+	for _, key := range value.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// mapEntry returns the value of the entry with the provided key.
+func mapEntry(value reflect.Value, key string) (any, bool) {
+	entry := value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+	if !entry.IsValid() {
+		return nil, false
+	}
+	return entry.Interface(), true
+}
+
+// mapOutput returns the outputs as a map of the same type as the provided map,
+// if all of them can be assigned to its value type.
+func mapOutput(value reflect.Value, outputs map[string]any) any {
+	valueType := value.Type().Elem()
+	typed := reflect.MakeMapWithSize(value.Type(), len(outputs))
+	for key, output := range outputs {
+		outputValue := reflect.Zero(valueType)
+		if output != nil {
+			outputValue = reflect.ValueOf(output)
+			if !outputValue.Type().AssignableTo(valueType) {
+				return outputs
+			}
+		} else if !isNillable(valueType.Kind()) {
+			return outputs
+		}
+		typed.SetMapIndex(reflect.ValueOf(key).Convert(value.Type().Key()), outputValue)
+	}
+	return typed.Interface()
+}
+
+// Keys check if every key of the provided map passes the provided rules, e.g.
+// Keys(Regexp(`^[a-z][a-z0-9_]*$`)) for label names. The issues of every
+// failing key are reported with the key e.g. "labels.Env". The keys are only
+// checked, the returned map is the provided one.
+func Keys(rules ...Rule) Rule {
+	return func(input any) (any, error) {
+		value, ok := stringMap(input)
+		if !ok {
+			return nil, mapIssue(CODE_KEYS)
+		}
+
+		var errs []error
+		failed := false
+		for _, key := range sortedKeys(value) {
+			_, issues, blocking, errConfig := runRules(rules, key, keyPath(key))
+			if errConfig != nil {
+				return nil, errConfig
+			}
+
+			errs = append(errs, issues...)
+			failed = failed || blocking
+		}
+
+		if failed {
+			return nil, errors.Join(errs...)
+		}
+		return input, errors.Join(errs...)
+	}
+}
+
+// Values check if every value of the provided map passes the provided rules.
+// The issues of every failing value are reported with its key e.g.
+// "labels.env". The returned value is a map of the outputs of the rules, of
+// the same type as the input when possible and map[string]any otherwise.
+func Values(rules ...Rule) Rule {
+	return func(input any) (any, error) {
+		value, ok := stringMap(input)
+		if !ok {
+			return nil, mapIssue(CODE_VALUES)
+		}
+
+		outputs := make(map[string]any, value.Len())
+		var errs []error
+		failed := false
+		for _, key := range sortedKeys(value) {
+			entry, _ := mapEntry(value, key)
+			output, issues, blocking, errConfig := runRules(rules, entry, keyPath(key))
+			if errConfig != nil {
+				return nil, errConfig
+			}
+
+			outputs[key] = output
+			errs = append(errs, issues...)
+			failed = failed || blocking
+		}
+
+		if failed {
+			return nil, errors.Join(errs...)
+		}
+		return mapOutput(value, outputs), errors.Join(errs...)
+	}
+}
+
+// MinEntries check if the provided map has at least the provided number of
+// entries.
+func MinEntries(count int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_MIN_ENTRIES,
+			Message: fmt.Sprintf("Please provide at least %d entries", count),
+			Value:   count,
+		}
+
+		value, ok := stringMap(input)
+		if !ok {
+			return nil, mapIssue(CODE_MIN_ENTRIES)
+		}
+
+		if value.Len() < count {
+			return nil, issue
+		}
+		return input, nil
+	}
+}
+
+// MaxEntries check if the provided map has at most the provided number of
+// entries.
+func MaxEntries(count int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_MAX_ENTRIES,
+			Message: fmt.Sprintf("Please provide at most %d entries", count),
+			Value:   count,
+		}
+
+		value, ok := stringMap(input)
+		if !ok {
+			return nil, mapIssue(CODE_MAX_ENTRIES)
+		}
+
+		if value.Len() > count {
+			return nil, issue
+		}
+		return input, nil
+	}
+}
+
+// Map check if the provided map has all of the required keys, and that the
+// value of each of the provided keys passes its rules e.g.
+//
+//	Map(UNKNOWN_KEYS_STRICT,
+//		RequiredKey("env", Enum("dev", "prod")),
+//		OptionalKey("team", NonEmptyString),
+//	)
+//
+// Keys which aren't listed are handled according to the provided policy, one
+// of the UNKNOWN_KEYS_* values. Issues are reported with the key e.g.
+// "labels.env". The returned value is a map of the outputs of the rules, of
+// the same type as the input when possible and map[string]any otherwise.
+func Map(unknownKeys string, keys ...MapKey) Rule {
+	policies := []string{UNKNOWN_KEYS_STRICT, UNKNOWN_KEYS_STRIP, UNKNOWN_KEYS_PASSTHROUGH}
+	listed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		listed[key.Key] = true
+	}
+
+	return func(input any) (any, error) {
+		if !slices.Contains(policies, unknownKeys) {
+			return nil, ConfigError{Rule: "Map", Message: fmt.Sprintf("unknown policy for unknown keys '%s'", unknownKeys)}
+		}
+
+		value, ok := stringMap(input)
+		if !ok {
+			return nil, mapIssue(CODE_MAP)
+		}
+
+		outputs := make(map[string]any, value.Len())
+		var errs []error
+		failed := false
+		for _, key := range keys {
+			entry, exists := mapEntry(value, key.Key)
+			if !exists {
+				if key.Required {
+					errs = append(errs, Issue{
+						Code:    CODE_REQUIRED_KEY,
+						Message: "This field is required",
+						Value:   key.Key,
+						Path:    keyPath(key.Key),
+					})
+					failed = true
+				}
+				continue
+			}
+
+			output, issues, blocking, errConfig := runRules(key.Rules, entry, keyPath(key.Key))
+			if errConfig != nil {
+				return nil, errConfig
+			}
+
+			outputs[key.Key] = output
+			errs = append(errs, issues...)
+			failed = failed || blocking
+		}
+
+		for _, key := range sortedKeys(value) {
+			if listed[key] {
+				continue
+			}
+
+			switch unknownKeys {
+			case UNKNOWN_KEYS_STRICT:
+				errs = append(errs, Issue{
+					Code:    CODE_UNKNOWN_KEY,
+					Message: "This field is not allowed",
+					Value:   key,
+					Path:    keyPath(key),
+				})
+				failed = true
+			case UNKNOWN_KEYS_PASSTHROUGH:
+				outputs[key], _ = mapEntry(value, key)
+			}
+		}
+
+		if failed {
+			return nil, errors.Join(errs...)
+		}
+		return mapOutput(value, outputs), errors.Join(errs...)
+	}
+}
@@ -0,0 +1,157 @@
+package vld
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+/**
+ * Rule: Keys, Values
+ *
+ */
+func TestKeys(t *testing.T) {
+	rule := Keys(Regexp(`^[a-z][a-z0-9_]*$`))
+	if _, err := rule(map[string]string{"env": "prod", "team_id": "7"}); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	_, err := rule(map[string]any{"env": "prod", "Team": "7", "1st": true})
+	issues := Issues(err)
+	if len(issues) != 2 || issues[0].Path != ".1st" || issues[1].Path != ".Team" {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+
+	if _, err := rule([]string{"env"}); !errors.Is(err, ErrKeys) {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+}
+
+func TestValues(t *testing.T) {
+	v, err := Values(TrimSpace, NonEmptyString)(map[string]string{"env": " prod "})
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(v, map[string]string{"env": "prod"}) {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	v, err = Values(ToBool)(map[string]any{"dark_mode": "on", "beta": "no"})
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(v, map[string]any{"dark_mode": true, "beta": false}) {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	_, err = Values(ToBool)(map[string]any{"dark_mode": "maybe"})
+	issues := Issues(err)
+	if len(issues) != 1 || issues[0].Path != ".dark_mode" || issues[0].Code != CODE_BOOL {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+}
+
+/**
+ * Rule: MinEntries, MaxEntries
+ *
+ */
+func TestMinMaxEntries(t *testing.T) {
+	labels := map[string]string{"env": "prod", "team": "core"}
+	if _, err := MinEntries(2)(labels); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := MaxEntries(1)(labels); !errors.Is(err, ErrMaxEntries) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := MinEntries(3)(labels); !errors.Is(err, ErrMinEntries) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: Map
+ *
+ */
+func TestMap(t *testing.T) {
+	keys := []MapKey{
+		RequiredKey("env", Enum("dev", "prod")),
+		OptionalKey("team", TrimSpace, NonEmptyString),
+	}
+
+	v, err := Map(UNKNOWN_KEYS_STRIP, keys...)(map[string]any{"env": "prod", "team": " core ", "debug": true})
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(v, map[string]any{"env": "prod", "team": "core"}) {
+		t.Errorf("unexpected output %v", v)
+		return
+	}
+
+	v, err = Map(UNKNOWN_KEYS_PASSTHROUGH, keys...)(map[string]string{"env": "dev", "debug": "1"})
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(v, map[string]string{"env": "dev", "debug": "1"}) {
+		t.Errorf("unexpected output %v", v)
+		return
+	}
+
+	_, err = Map(UNKNOWN_KEYS_STRICT, keys...)(map[string]any{"team": "", "debug": true})
+	issues := Issues(err)
+	expected := []string{CODE_REQUIRED_KEY + ".env", CODE_NON_EMPTY_STRING + ".team", CODE_UNKNOWN_KEY + ".debug"}
+	if len(issues) != len(expected) {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+
+	for i, issue := range issues {
+		if issue.Code+issue.Path != expected[i] {
+			t.Errorf("unexpected issue %v", issue)
+			return
+		}
+	}
+}
+
+func TestMapConfigError(t *testing.T) {
+	_, err := Map("ignore")(map[string]any{})
+	var errConfig ConfigError
+	if !errors.As(err, &errConfig) {
+		t.Error("unknown policy must be reported as a ConfigError")
+		return
+	}
+}
+
+func TestValidateMapPaths(t *testing.T) {
+	err := Validate([]Validation{
+		{
+			Tag:   "labels",
+			Data:  map[string]string{"env": "staging"},
+			Rules: []Rule{Map(UNKNOWN_KEYS_STRICT, RequiredKey("env", Enum("dev", "prod")))},
+		},
+	})
+
+	var errValidation ValidationErrors
+	if !errors.As(err, &errValidation) || errValidation.Errors["labels.env"].Code != CODE_ENUM {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+}
@@ -0,0 +1,129 @@
+package vld
+
+import (
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
+// HexColor check if the provided input is a color in hexadecimal notation:
+// #RGB, #RGBA, #RRGGBB or #RRGGBBAA. The returned value is the parsed Color.
+func HexColor(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_HEX_COLOR,
+		Message: "Please provide a valid hex color e.g. #ff0000",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	color, err := ParseHexColor(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return color, nil
+}
+
+// CSSColor check if the provided input is a CSS color in hexadecimal notation
+// or using the rgb(), rgba(), hsl() and hsla() functions, e.g.
+// "rgb(255 0 0 / 50%)" (see ParseColor). Named colors such as "red" aren't
+// accepted. The returned value is the parsed Color.
+func CSSColor(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_COLOR,
+		Message: "Please provide a valid color",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	color, err := ParseColor(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return color, nil
+}
+
+// ParsedMediaType is a MIME type parsed by MIMEType.
+type ParsedMediaType struct {
+	// MediaType is the lower-cased type and subtype e.g. "text/html".
+	MediaType string
+
+	// Params holds the parameters of the type e.g. "charset".
+	Params map[string]string
+}
+
+// MIMEType check if the provided input is a valid MIME type, with optional
+// parameters, e.g. "text/html; charset=utf-8", matching one of the provided
+// types. Types ending with "/*", e.g. "image/*", allow all of their subtypes,
+// and providing no type allows any. The returned value is the
+// ParsedMediaType, as parsed by mime.ParseMediaType.
+func MIMEType(mediaTypes ...string) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_MIME_TYPE,
+			Message: "Please provide a valid MIME type",
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		mediaType, params, err := mime.ParseMediaType(asString)
+		if err != nil || strings.Count(mediaType, "/") != 1 || strings.HasPrefix(mediaType, "/") || strings.HasSuffix(mediaType, "/") {
+			return nil, issue
+		}
+
+		if len(mediaTypes) != 0 && !mediaTypeAllowed(mediaType, mediaTypes) {
+			issue.Message = fmt.Sprintf("Please provide a MIME type of %s", strings.Join(mediaTypes, ", "))
+			issue.Value = mediaTypes
+			return nil, issue
+		}
+		return ParsedMediaType{MediaType: mediaType, Params: params}, nil
+	}
+}
+
+// FileExtension check if the provided input is a file name, or an extension,
+// ending with one of the provided extensions e.g. FileExtension(".jpg",
+// ".png"). Extensions are matched case-insensitively, may have more than one
+// part e.g. ".tar.gz", and their leading dot is optional. Providing no
+// extension allows any, as long as the file name has one.
+func FileExtension(extensions ...string) Rule {
+	allowed := make([]string, len(extensions))
+	for i, extension := range extensions {
+		allowed[i] = "." + strings.TrimPrefix(strings.ToLower(extension), ".")
+	}
+
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_FILE_EXTENSION,
+			Message: "Please provide a file name with a valid extension",
+		}
+
+		asString, ok := input.(string)
+		if !ok || len(filepath.Ext(asString)) < 2 {
+			return nil, issue
+		}
+
+		if len(allowed) == 0 {
+			return asString, nil
+		}
+
+		name := strings.ToLower(asString)
+		for _, extension := range allowed {
+			if strings.HasSuffix(name, extension) {
+				return asString, nil
+			}
+		}
+
+		issue.Message = fmt.Sprintf("Please provide a file of type %s", strings.Join(allowed, ", "))
+		issue.Value = allowed
+		return nil, issue
+	}
+}
@@ -0,0 +1,127 @@
+package vld
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+/**
+ * Rule: HexColor
+ *
+ */
+func TestHexColor(t *testing.T) {
+	v, err := HexColor("#FF0000")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if v != (Color{R: 255, A: 1}) {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	for _, input := range []any{"rgb(255, 0, 0)", "#ff000", "ff0000", 0xff0000} {
+		if _, err := HexColor(input); !errors.Is(err, ErrHexColor) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: CSSColor
+ *
+ */
+func TestCSSColor(t *testing.T) {
+	for _, input := range []string{"#fff", "rgb(1, 2, 3)", "hsl(120deg 50% 50% / 10%)"} {
+		v, err := CSSColor(input)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if _, ok := v.(Color); !ok {
+			t.Error(errInvalidReturnType)
+			return
+		}
+	}
+
+	for _, input := range []any{"red", "rgb(1, 2)", "", nil} {
+		if _, err := CSSColor(input); !errors.Is(err, ErrColor) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: MIMEType
+ *
+ */
+func TestMIMEType(t *testing.T) {
+	v, err := MIMEType()("Text/HTML; charset=UTF-8")
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	expected := ParsedMediaType{MediaType: "text/html", Params: map[string]string{"charset": "UTF-8"}}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("unexpected media type %v", v)
+		return
+	}
+
+	rule := MIMEType("image/*", "application/pdf")
+	for _, input := range []string{"image/png", "image/svg+xml", "application/pdf"} {
+		if _, err := rule(input); err != nil {
+			t.Errorf("%s: %s", input, err.Error())
+			return
+		}
+	}
+
+	for _, input := range []any{"text/plain", "application/pdfx", "imagepng", "image/", "/png", "image/png/x", 42} {
+		if _, err := rule(input); !errors.Is(err, ErrMIMEType) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: FileExtension
+ *
+ */
+func TestFileExtension(t *testing.T) {
+	rule := FileExtension(".jpg", "png", ".tar.gz")
+	for _, input := range []string{"photo.jpg", "PHOTO.JPG", "image.png", ".png", "backup.tar.gz"} {
+		v, err := rule(input)
+		if err != nil {
+			t.Errorf("%s: %s", input, err.Error())
+			return
+		}
+
+		if v != input {
+			t.Error(errInvalidReturnType)
+			return
+		}
+	}
+
+	for _, input := range []any{"photo.gif", "photo", "photo.", "backup.gz", "photo.jpg.exe", 42} {
+		if _, err := rule(input); !errors.Is(err, ErrFileExtension) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+
+	if _, err := FileExtension()("notes.txt"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := FileExtension()("Makefile"); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
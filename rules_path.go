@@ -0,0 +1,189 @@
+package vld
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Path rules accept both slashes and backslashes as separators, since paths
+// such as archive entry names may come from any operating system. The paths
+// they return use slashes.
+
+// cleanPath returns the provided path cleaned using filepath.Clean, with
+// slashes as separators.
+func cleanPath(input string) string {
+	return filepath.ToSlash(filepath.Clean(strings.ReplaceAll(input, `\`, "/")))
+}
+
+// relativePath reports whether the provided slash-separated path is relative,
+// which rules out Windows volumes e.g. "C:" and UNC paths as well.
+func relativePath(input string) bool {
+	return !strings.HasPrefix(input, "/") && filepath.VolumeName(input) == "" && !(len(input) >= 2 && input[1] == ':')
+}
+
+// SafeRelativePath check if the provided input is a relative path which stays
+// within the directory it's relative to, e.g. the name of an archive entry.
+// Absolute paths, paths with a drive letter and paths escaping the directory
+// using ".." once cleaned are rejected. The returned value is the cleaned path
+// e.g. "a/c" for "a/b/../c".
+func SafeRelativePath(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_SAFE_PATH,
+		Message: "Please provide a valid relative path",
+	}
+
+	asString, ok := input.(string)
+	if !ok || asString == "" || strings.ContainsRune(asString, 0) {
+		return nil, issue
+	}
+
+	cleaned := cleanPath(asString)
+	if !relativePath(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return nil, issue
+	}
+	return cleaned, nil
+}
+
+// PathWithin check if the provided input is a path confined to the provided
+// base directory once cleaned, e.g. PathWithin("/srv/uploads"). Relative paths
+// are relative to the base directory. Symbolic links aren't resolved. The
+// returned value is the cleaned path joined with the base directory.
+func PathWithin(base string) Rule {
+	return func(input any) (any, error) {
+		if base == "" {
+			return nil, ConfigError{Rule: "PathWithin", Message: "base directory cannot be empty"}
+		}
+
+		issue := Issue{
+			Code:    CODE_PATH_WITHIN,
+			Message: fmt.Sprintf("The path must be within %s", base),
+			Value:   base,
+		}
+
+		asString, ok := input.(string)
+		if !ok || asString == "" || strings.ContainsRune(asString, 0) {
+			return nil, issue
+		}
+
+		root := cleanPath(base)
+		joined := cleanPath(asString)
+		if relativePath(joined) {
+			joined = cleanPath(root + "/" + joined)
+		}
+
+		relative, err := filepath.Rel(root, joined)
+		if err != nil {
+			return nil, issue
+		}
+
+		relative = filepath.ToSlash(relative)
+		if relative == ".." || strings.HasPrefix(relative, "../") {
+			return nil, issue
+		}
+		return joined, nil
+	}
+}
+
+// windowsReservedNames are the device names which can't be used as file
+// names on Windows, with or without an extension.
+var windowsReservedNames = []string{
+	"CON", "PRN", "AUX", "NUL",
+	"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+	"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+}
+
+// PortableFilename check if the provided input is a file name which is valid
+// on Windows, macOS and Linux: at most 255 bytes, without separators, control
+// characters or any of <>:"|?*, not ending with a space or a dot, and not a
+// reserved Windows device name such as "CON" or "nul.txt".
+func PortableFilename(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_FILENAME,
+		Message: "Please provide a valid file name",
+	}
+
+	asString, ok := input.(string)
+	if !ok || asString == "" || len(asString) > 255 || asString == "." || asString == ".." {
+		return nil, issue
+	}
+
+	if strings.ContainsAny(asString, `/\<>:"|?*`) || strings.ContainsFunc(asString, unicode.IsControl) {
+		return nil, issue
+	}
+
+	if strings.HasSuffix(asString, " ") || strings.HasSuffix(asString, ".") {
+		return nil, issue
+	}
+
+	stem, _, _ := strings.Cut(asString, ".")
+	if slices.Contains(windowsReservedNames, strings.ToUpper(strings.TrimRight(stem, " "))) {
+		issue.Message = fmt.Sprintf("'%s' is a reserved file name", asString)
+		return nil, issue
+	}
+	return asString, nil
+}
+
+// MaxPathDepth check if the provided input is a path with at most the provided
+// number of components once cleaned, e.g. "a/b/c.txt" has a depth of 3.
+func MaxPathDepth(depth int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_PATH_DEPTH,
+			Message: fmt.Sprintf("The path cannot be more than %d levels deep", depth),
+			Value:   depth,
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		cleaned := strings.Trim(cleanPath(asString), "/")
+		actual := 0
+		if cleaned != "." && cleaned != "" {
+			actual = strings.Count(cleaned, "/") + 1
+		}
+
+		if actual > depth {
+			return nil, issue
+		}
+		return asString, nil
+	}
+}
+
+// PathExists check if the provided input is the path of an existing file or
+// directory of the provided file system, e.g. PathExists(os.DirFS("/srv")) or
+// an fstest.MapFS in tests. Paths are cleaned and must be relative to the root
+// of the file system (see SafeRelativePath). The returned value is the cleaned
+// path.
+func PathExists(fsys fs.FS) Rule {
+	return func(input any) (any, error) {
+		if fsys == nil {
+			return nil, ConfigError{Rule: "PathExists", Message: "file system cannot be nil"}
+		}
+
+		issue := Issue{
+			Code:    CODE_PATH_EXISTS,
+			Message: "The path does not exist",
+		}
+
+		cleaned, err := SafeRelativePath(input)
+		if err != nil {
+			return nil, issue
+		}
+
+		name := cleaned.(string)
+		if !fs.ValidPath(name) {
+			return nil, issue
+		}
+
+		if _, err := fs.Stat(fsys, name); err != nil {
+			return nil, issue
+		}
+		return name, nil
+	}
+}
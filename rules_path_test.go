@@ -0,0 +1,160 @@
+package vld
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+/**
+ * Rule: SafeRelativePath
+ *
+ */
+func TestSafeRelativePath(t *testing.T) {
+	testCases := map[string]string{
+		"docs/readme.md": "docs/readme.md",
+		"./a/b/../c":     "a/c",
+		`dir\file.txt`:   "dir/file.txt",
+		"a/../b":         "b",
+		"a//b/":          "a/b",
+		"..hidden/file":  "..hidden/file",
+		"file..name.txt": "file..name.txt",
+	}
+	for input, expected := range testCases {
+		v, err := SafeRelativePath(input)
+		if err != nil {
+			t.Errorf("%s: %s", input, err.Error())
+			return
+		}
+
+		if v != expected {
+			t.Errorf("%s: unexpected path %v", input, v)
+			return
+		}
+	}
+
+	invalid := []any{"", "/etc/passwd", "../secret", "a/../../b", `..\windows`, `\\server\share`, "C:/Windows", `c:file`, "a\x00b", 42}
+	for _, input := range invalid {
+		if _, err := SafeRelativePath(input); !errors.Is(err, ErrSafePath) {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: PathWithin
+ *
+ */
+func TestPathWithin(t *testing.T) {
+	rule := PathWithin("/srv/uploads/")
+	testCases := map[string]string{
+		"avatar.png":                   "/srv/uploads/avatar.png",
+		"a/../b.txt":                   "/srv/uploads/b.txt",
+		"/srv/uploads/x/y.txt":         "/srv/uploads/x/y.txt",
+		"/srv/uploads":                 "/srv/uploads",
+		"/srv/uploads/../uploads/a.md": "/srv/uploads/a.md",
+	}
+	for input, expected := range testCases {
+		v, err := rule(input)
+		if err != nil {
+			t.Errorf("%s: %s", input, err.Error())
+			return
+		}
+
+		if v != expected {
+			t.Errorf("%s: unexpected path %v", input, v)
+			return
+		}
+	}
+
+	invalid := []any{"../etc/passwd", "/srv/uploads-other/a", "/etc/passwd", "a/../../../etc", "C:/x", "", 42}
+	for _, input := range invalid {
+		if _, err := rule(input); !errors.Is(err, ErrPathWithin) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+
+	var errConfig ConfigError
+	if _, err := PathWithin("")("a"); !errors.As(err, &errConfig) {
+		t.Error("expected a config error")
+		return
+	}
+}
+
+/**
+ * Rule: PortableFilename
+ *
+ */
+func TestPortableFilename(t *testing.T) {
+	for _, input := range []string{"report.pdf", ".gitignore", "my file (1).txt", "café.png", "CONSOLE.txt", "com10"} {
+		if _, err := PortableFilename(input); err != nil {
+			t.Errorf("%s: %s", input, err.Error())
+			return
+		}
+	}
+
+	invalid := []any{
+		"", ".", "..", "a/b", `a\b`, "a:b", "what?.txt", "a*b", "tab\tname", "trailing.", "trailing ",
+		"CON", "con.txt", "Nul", "LPT1.tar.gz", "aux .txt", string(make([]byte, 256)), 42,
+	}
+	for _, input := range invalid {
+		if _, err := PortableFilename(input); !errors.Is(err, ErrFilename) {
+			t.Errorf("%q: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: MaxPathDepth
+ *
+ */
+func TestMaxPathDepth(t *testing.T) {
+	rule := MaxPathDepth(2)
+	for _, input := range []string{".", "a", "a/b", "/a/b", "a/b/../c", `a\b`} {
+		if _, err := rule(input); err != nil {
+			t.Errorf("%s: %s", input, err.Error())
+			return
+		}
+	}
+
+	for _, input := range []any{"a/b/c", `a\b\c`, "/a/b/c/", 42} {
+		if _, err := rule(input); !errors.Is(err, ErrPathDepth) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: PathExists
+ *
+ */
+func TestPathExists(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docs/readme.md": {Data: []byte("# readme")},
+		"logo.png":       {Data: []byte{0x89}},
+	}
+	rule := PathExists(fsys)
+	for _, input := range []string{"docs/readme.md", "./logo.png", "docs", `docs\readme.md`} {
+		if _, err := rule(input); err != nil {
+			t.Errorf("%s: %s", input, err.Error())
+			return
+		}
+	}
+
+	for _, input := range []any{"missing.txt", "../logo.png", "/logo.png", 42} {
+		if _, err := rule(input); !errors.Is(err, ErrPathExists) {
+			t.Errorf("%v: %s", input, errInvalidPassed)
+			return
+		}
+	}
+
+	var errConfig ConfigError
+	if _, err := PathExists(nil)("a"); !errors.As(err, &errConfig) {
+		t.Error("expected a config error")
+		return
+	}
+}
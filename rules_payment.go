@@ -0,0 +1,410 @@
+package vld
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CARD_VISA       = "visa"
+	CARD_MASTERCARD = "mastercard"
+	CARD_AMEX       = "amex"
+	CARD_DISCOVER   = "discover"
+	CARD_DINERS     = "diners"
+	CARD_JCB        = "jcb"
+	CARD_UNIONPAY   = "unionpay"
+	CARD_MAESTRO    = "maestro"
+)
+
+var bicPattern = regexp.MustCompile(PATTERN_BIC)
+
+// cardIINRange is an inclusive range of Issuer Identification Numbers. Both
+// bounds must have the same number of digits.
+type cardIINRange struct {
+	low  string
+	high string
+}
+
+type cardScheme struct {
+	brand   string
+	ranges  []cardIINRange
+	lengths []int
+}
+
+// cardSchemes are checked in order, more specific ranges must therefore be
+// listed before the broader ranges they overlap with (e.g. Discover's
+// co-branded 622126-622925 before UnionPay's 62).
+var cardSchemes = []cardScheme{
+	{
+		brand:   CARD_AMEX,
+		ranges:  []cardIINRange{{"34", "34"}, {"37", "37"}},
+		lengths: []int{15},
+	},
+	{
+		brand:   CARD_DINERS,
+		ranges:  []cardIINRange{{"300", "305"}, {"36", "36"}, {"38", "39"}},
+		lengths: []int{14, 15, 16, 17, 18, 19},
+	},
+	{
+		brand:   CARD_JCB,
+		ranges:  []cardIINRange{{"3528", "3589"}},
+		lengths: []int{16, 17, 18, 19},
+	},
+	{
+		brand:   CARD_DISCOVER,
+		ranges:  []cardIINRange{{"6011", "6011"}, {"644", "649"}, {"65", "65"}, {"622126", "622925"}},
+		lengths: []int{16, 17, 18, 19},
+	},
+	{
+		brand:   CARD_UNIONPAY,
+		ranges:  []cardIINRange{{"62", "62"}},
+		lengths: []int{16, 17, 18, 19},
+	},
+	{
+		brand: CARD_MAESTRO,
+		ranges: []cardIINRange{
+			{"5018", "5018"}, {"5020", "5020"}, {"5038", "5038"}, {"5893", "5893"},
+			{"6304", "6304"}, {"6759", "6759"}, {"6761", "6763"},
+		},
+		lengths: []int{12, 13, 14, 15, 16, 17, 18, 19},
+	},
+	{
+		brand:   CARD_MASTERCARD,
+		ranges:  []cardIINRange{{"51", "55"}, {"2221", "2720"}},
+		lengths: []int{16},
+	},
+	{
+		brand:   CARD_VISA,
+		ranges:  []cardIINRange{{"4", "4"}},
+		lengths: []int{13, 16, 19},
+	},
+}
+
+// ibanLengths lists the total IBAN length for each country participating in
+// the IBAN registry.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28,
+	"CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24,
+	"FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18,
+	"GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23,
+	"IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32,
+	"LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22,
+	"MK": 19, "MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24,
+	"SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+// stripCardSeparators removes the spaces and dashes commonly used to group
+// the digits of card, account and routing numbers.
+func stripCardSeparators(input string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(input)
+}
+
+func isDigits(input string) bool {
+	if input == "" {
+		return false
+	}
+
+	for _, r := range input {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// luhnValid runs the Luhn (mod 10) checksum over a string of digits.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// detectCardBrand finds the card scheme of the provided card number based on
+// its IIN prefix.
+func detectCardBrand(digits string) (cardScheme, bool) {
+	for _, scheme := range cardSchemes {
+		for _, r := range scheme.ranges {
+			if len(digits) < len(r.low) {
+				continue
+			}
+
+			prefix := digits[:len(r.low)]
+			if prefix >= r.low && prefix <= r.high {
+				return scheme, true
+			}
+		}
+	}
+	return cardScheme{}, false
+}
+
+// CreditCard check if the provided input is a valid payment card number. The
+// number must pass the Luhn checksum, belong to a known brand and have a valid
+// length for that brand. Spaces and dashes are stripped from the returned
+// value. The card number is never included in the returned issue.
+func CreditCard(input any) (any, error) {
+	return CreditCardBrand()(input)
+}
+
+// CreditCardBrand check if the provided input is a valid payment card number
+// (see CreditCard) issued by one of the provided brands. When no brands are
+// provided, all known brands are accepted.
+func CreditCardBrand(brands ...string) Rule {
+	errConfig := cardBrandError("CreditCardBrand", brands...)
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		issue := Issue{
+			Code:    CODE_CREDIT_CARD,
+			Message: "Please provide a valid card number",
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		digits := stripCardSeparators(asString)
+		if !isDigits(digits) || !luhnValid(digits) {
+			return nil, issue
+		}
+
+		scheme, ok := detectCardBrand(digits)
+		if !ok || !slices.Contains(scheme.lengths, len(digits)) {
+			return nil, issue
+		}
+
+		if len(brands) != 0 && !slices.Contains(brands, scheme.brand) {
+			return nil, Issue{
+				Code:    CODE_CREDIT_CARD,
+				Message: fmt.Sprintf("The card must be one of %s", strings.Join(brands, ", ")),
+				Value:   brands,
+			}
+		}
+
+		return digits, nil
+	}
+}
+
+// cardBrandError returns a ConfigError when any of the provided brands is not
+// one of the known CARD_* brands.
+func cardBrandError(rule string, brands ...string) error {
+	for _, brand := range brands {
+		known := slices.ContainsFunc(cardSchemes, func(scheme cardScheme) bool {
+			return scheme.brand == brand
+		})
+
+		if !known {
+			return ConfigError{Rule: rule, Message: fmt.Sprintf("unknown card brand '%s'", brand)}
+		}
+	}
+	return nil
+}
+
+// CardBrand returns the brand of the provided card number, e.g. CARD_VISA. An
+// empty string is returned when the number doesn't belong to a known brand.
+func CardBrand(number string) string {
+	scheme, ok := detectCardBrand(stripCardSeparators(number))
+	if !ok {
+		return ""
+	}
+	return scheme.brand
+}
+
+// CVV check if the provided input is a valid card security code for the
+// provided brand. American Express cards use four digits, all other brands use
+// three. The code is never included in the returned issue.
+func CVV(brand string) Rule {
+	errConfig := cardBrandError("CVV", brand)
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		length := 3
+		if brand == CARD_AMEX {
+			length = 4
+		}
+
+		issue := Issue{
+			Code:    CODE_CVV,
+			Message: fmt.Sprintf("The security code must be %d digits", length),
+			Value:   length,
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		asString = strings.TrimSpace(asString)
+		if len(asString) != length || !isDigits(asString) {
+			return nil, issue
+		}
+		return asString, nil
+	}
+}
+
+// CardExpiry check if the provided input is a card expiry date in the format
+// MM/YY or MM/YYYY, and that the card has not expired yet. A card remains valid
+// until the end of its expiry month, according to the current time returned
+// by the provided clock. The returned value is a time.Time set to the first
+// day of the expiry month.
+func CardExpiry(clock Clock) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_CARD_EXPIRY,
+			Message: "Please provide a valid expiry date",
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		month, year, found := strings.Cut(strings.ReplaceAll(asString, " ", ""), "/")
+		// the digits are checked first, as strconv.Atoi also accepts a sign e.g. "+1".
+		if !found || len(month) != 2 || (len(year) != 2 && len(year) != 4) || !isDigits(month) || !isDigits(year) {
+			return nil, issue
+		}
+
+		monthNumber, errMonth := strconv.Atoi(month)
+		yearNumber, errYear := strconv.Atoi(year)
+		if errMonth != nil || errYear != nil || monthNumber < 1 || monthNumber > 12 {
+			return nil, issue
+		}
+
+		if len(year) == 2 {
+			yearNumber += 2000
+		}
+
+		expiry := time.Date(yearNumber, time.Month(monthNumber), 1, 0, 0, 0, 0, time.UTC)
+		if !clock.now().UTC().Before(expiry.AddDate(0, 1, 0)) {
+			return nil, Issue{
+				Code:    CODE_CARD_EXPIRY,
+				Message: "The card has expired",
+			}
+		}
+
+		return expiry, nil
+	}
+}
+
+// IBAN check if the provided input is a valid International Bank Account
+// Number. The length is checked against the country of the account and the
+// mod-97 checksum is verified. The returned value is upper-case with spaces
+// removed.
+func IBAN(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_IBAN,
+		Message: "Please provide a valid IBAN",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	iban := strings.ToUpper(strings.ReplaceAll(asString, " ", ""))
+	if len(iban) < 4 {
+		return nil, issue
+	}
+
+	length, ok := ibanLengths[iban[:2]]
+	if !ok || len(iban) != length {
+		return nil, issue
+	}
+
+	// move the country code and check digits to the end and replace letters
+	// with their numeric value, i.e. A = 10, B = 11 ... Z = 35.
+	var numeric strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			numeric.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			numeric.WriteString(strconv.Itoa(int(r-'A') + 10))
+		default:
+			return nil, issue
+		}
+	}
+
+	asNumber, ok := new(big.Int).SetString(numeric.String(), 10)
+	if !ok || new(big.Int).Mod(asNumber, big.NewInt(97)).Int64() != 1 {
+		return nil, issue
+	}
+
+	return iban, nil
+}
+
+// BIC check if the provided input is a valid Business Identifier Code (also
+// known as a SWIFT code). The returned value is upper-case.
+func BIC(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_BIC,
+		Message: "Please provide a valid BIC / SWIFT code",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	bic := strings.ToUpper(strings.TrimSpace(asString))
+	if !bicPattern.MatchString(bic) {
+		return nil, issue
+	}
+	return bic, nil
+}
+
+// RoutingNumber check if the provided input is a valid US ABA routing transit
+// number, including its checksum digit.
+func RoutingNumber(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_ROUTING_NUMBER,
+		Message: "Please provide a valid routing number",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	digits := stripCardSeparators(asString)
+	if len(digits) != 9 || !isDigits(digits) {
+		return nil, issue
+	}
+
+	weights := [3]int{3, 7, 1}
+	sum := 0
+	for i := range digits {
+		sum += int(digits[i]-'0') * weights[i%3]
+	}
+
+	if sum%10 != 0 {
+		return nil, issue
+	}
+	return digits, nil
+}
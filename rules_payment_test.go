@@ -0,0 +1,240 @@
+package vld
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+/**
+ * Rule: CreditCard
+ *
+ */
+func TestCreditCardValidInput(t *testing.T) {
+	testCases := []struct {
+		input string
+		brand string
+	}{
+		{input: "4111 1111 1111 1111", brand: CARD_VISA},
+		{input: "5555-5555-5555-4444", brand: CARD_MASTERCARD},
+		{input: "2223003122003222", brand: CARD_MASTERCARD},
+		{input: "378282246310005", brand: CARD_AMEX},
+		{input: "6011111111111117", brand: CARD_DISCOVER},
+		{input: "30569309025904", brand: CARD_DINERS},
+		{input: "3530111333300000", brand: CARD_JCB},
+		{input: "6200000000000005", brand: CARD_UNIONPAY},
+	}
+
+	for _, testCase := range testCases {
+		v, err := CreditCard(testCase.input)
+		if err != nil {
+			t.Errorf(errValidFailed, testCase.input)
+			return
+		}
+
+		asString, ok := v.(string)
+		if !ok || strings.ContainsAny(asString, " -") {
+			t.Error(errInvalidReturnType)
+			return
+		}
+
+		if brand := CardBrand(testCase.input); brand != testCase.brand {
+			t.Errorf("unexpected brand %s for %s", brand, testCase.input)
+			return
+		}
+	}
+}
+
+func TestCreditCardInvalidInput(t *testing.T) {
+	inputs := []string{
+		"4111 1111 1111 1112", // luhn failure
+		"4111 1111 1111",      // invalid visa length
+		"9111111111111111",    // unknown brand
+		"4111-abcd-1111-1111",
+		"",
+	}
+
+	for _, input := range inputs {
+		_, err := CreditCard(input)
+		if err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+
+		issue, ok := err.(Issue)
+		if !ok || issue.Value != nil {
+			t.Error("card number leaked into issue")
+			return
+		}
+	}
+}
+
+func TestCreditCardInvalidInputType(t *testing.T) {
+	if _, err := CreditCard(4111111111111111); err == nil {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+}
+
+func TestCreditCardBrand(t *testing.T) {
+	v := CreditCardBrand(CARD_VISA, CARD_MASTERCARD)
+	if _, err := v("4111111111111111"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := v("378282246310005"); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: CVV
+ *
+ */
+func TestCVV(t *testing.T) {
+	if _, err := CVV(CARD_VISA)("123"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := CVV(CARD_AMEX)("1234"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	inputs := []any{"1234", "12a", "12", 123}
+	for _, input := range inputs {
+		if _, err := CVV(CARD_VISA)(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: CardExpiry
+ *
+ */
+func TestCardExpiry(t *testing.T) {
+	now := time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC)
+	rule := CardExpiry(fixedClock(now))
+	for _, input := range []string{"03/24", "04/2026"} {
+		v, err := rule(input)
+		if err != nil {
+			t.Errorf(errValidFailed, input)
+			return
+		}
+
+		if _, ok := v.(time.Time); !ok {
+			t.Error(errInvalidReturnType)
+			return
+		}
+	}
+
+	invalid := []any{
+		"02/24",
+		"13/30",
+		"1/30",
+		"+1/30",
+		"01/+030",
+		"0130",
+		1230,
+	}
+
+	for _, input := range invalid {
+		if _, err := rule(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+
+	if _, err := CardExpiry(nil)(time.Now().AddDate(1, 0, 0).Format("01/06")); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+}
+
+/**
+ * Rule: IBAN
+ *
+ */
+func TestIBANValidInput(t *testing.T) {
+	inputs := []string{
+		"GB82 WEST 1234 5698 7654 32",
+		"DE89370400440532013000",
+		"fr1420041010050500013m02606",
+	}
+
+	for _, input := range inputs {
+		v, err := IBAN(input)
+		if err != nil {
+			t.Errorf(errValidFailed, input)
+			return
+		}
+
+		asString, ok := v.(string)
+		if !ok || strings.Contains(asString, " ") || strings.ToUpper(asString) != asString {
+			t.Error(errInvalidReturnType)
+			return
+		}
+	}
+}
+
+func TestIBANInvalidInput(t *testing.T) {
+	inputs := []any{
+		"GB82 WEST 1234 5698 7654 33", // checksum failure
+		"DE8937040044053201300",       // invalid length
+		"XX82WEST12345698765432",      // unknown country
+		"GB",
+		false,
+	}
+
+	for _, input := range inputs {
+		if _, err := IBAN(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: BIC
+ *
+ */
+func TestBIC(t *testing.T) {
+	for _, input := range []string{"DEUTDEFF", "nedszajjxxx"} {
+		if _, err := BIC(input); err != nil {
+			t.Errorf(errValidFailed, input)
+			return
+		}
+	}
+
+	for _, input := range []any{"DEUTDEF", "DEUT1EFF", "DEUTDEFFXX", 10} {
+		if _, err := BIC(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: RoutingNumber
+ *
+ */
+func TestRoutingNumber(t *testing.T) {
+	for _, input := range []string{"011000015", "021000021"} {
+		if _, err := RoutingNumber(input); err != nil {
+			t.Errorf(errValidFailed, input)
+			return
+		}
+	}
+
+	for _, input := range []any{"011000016", "01100001", "abcdefghi", 11000015} {
+		if _, err := RoutingNumber(input); err == nil {
+			t.Error(errInvalidPassed)
+			return
+		}
+	}
+}
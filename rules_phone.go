@@ -0,0 +1,151 @@
+package vld
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	PHONE_MOBILE     = "mobile"
+	PHONE_FIXED_LINE = "fixed-line"
+)
+
+// phoneSeparators are stripped from phone numbers before they are parsed.
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "")
+
+// matchPhoneType reports whether the national significant number is valid in
+// the provided region and of one of the provided types.
+func matchPhoneType(region phoneRegion, nsn string, types []string) bool {
+	patterns := [...]struct {
+		phoneType string
+		pattern   *regexp.Regexp
+	}{
+		{PHONE_MOBILE, region.mobile},
+		{PHONE_FIXED_LINE, region.fixedLine},
+	}
+
+	for _, candidate := range patterns {
+		if len(types) != 0 && !slices.Contains(types, candidate.phoneType) {
+			continue
+		}
+
+		if candidate.pattern.MatchString(nsn) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePhone parses a phone number in international format, or in national
+// format for the default region, and returns it in E.164 format.
+func parsePhone(input string, defaultRegion string, types []string) (string, bool) {
+	// the trunk prefix is often written in parentheses after the calling
+	// code, e.g. +44 (0)20 7946 0958, and must not be dialed.
+	number := phoneSeparators.Replace(strings.Replace(input, "(0)", "", 1))
+	international := false
+
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number = number[1:]
+		international = true
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+		international = true
+	case strings.HasPrefix(number, "011") && phoneRegions[defaultRegion].callingCode == "1":
+		number = number[3:]
+		international = true
+	}
+
+	if !isDigits(number) {
+		return "", false
+	}
+
+	if !international {
+		region, ok := phoneRegions[defaultRegion]
+		if !ok {
+			return "", false
+		}
+
+		candidates := []string{number}
+		if region.trunkPrefix != "" && strings.HasPrefix(number, region.trunkPrefix) {
+			candidates = append(candidates, number[len(region.trunkPrefix):])
+		}
+
+		for _, nsn := range candidates {
+			if matchPhoneType(region, nsn, types) {
+				return "+" + region.callingCode + nsn, true
+			}
+		}
+		return "", false
+	}
+
+	// regions may share a calling code, e.g. US and CA share "1", so they are
+	// tried in a deterministic order, the first matching one being used.
+	for _, code := range PhoneRegions() {
+		region := phoneRegions[code]
+		nsn, found := strings.CutPrefix(number, region.callingCode)
+		if found && matchPhoneType(region, nsn, types) {
+			return "+" + number, true
+		}
+	}
+	return "", false
+}
+
+// Phone check if the provided input is a valid phone number. Numbers in
+// international format (+44 20 7946 0958 or 0044...) are accepted for any
+// bundled region, while numbers in national format (020 7946 0958) are parsed
+// for the provided default region. When types are provided, e.g.
+// PHONE_MOBILE, the number must be of one of those types. Spaces, dashes,
+// dots and parentheses are ignored. The returned value is the number in E.164
+// format, e.g. +442079460958. An empty default region only accepts numbers in
+// international format.
+func Phone(defaultRegion string, types ...string) Rule {
+	defaultRegion = strings.ToUpper(defaultRegion)
+	_, regionSupported := phoneRegions[defaultRegion]
+
+	return func(input any) (any, error) {
+		if defaultRegion != "" && !regionSupported {
+			return nil, ConfigError{Rule: "Phone", Message: fmt.Sprintf("unsupported default region '%s'", defaultRegion)}
+		}
+
+		for _, phoneType := range types {
+			if phoneType != PHONE_MOBILE && phoneType != PHONE_FIXED_LINE {
+				return nil, ConfigError{Rule: "Phone", Message: fmt.Sprintf("unknown phone type '%s'", phoneType)}
+			}
+		}
+
+		issue := Issue{
+			Code:    CODE_PHONE,
+			Message: "Please provide a valid phone number",
+		}
+
+		if len(types) != 0 {
+			issue.Message = fmt.Sprintf("Please provide a valid %s phone number", strings.Join(types, " or "))
+			issue.Value = types
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		e164, ok := parsePhone(asString, defaultRegion, types)
+		if !ok {
+			return nil, issue
+		}
+		return e164, nil
+	}
+}
+
+// PhoneRegions returns the ISO 3166-1 alpha-2 codes of the regions whose
+// numbering plans are bundled and can be checked with Phone.
+func PhoneRegions() []string {
+	regions := make([]string, 0, len(phoneRegions))
+	for region := range phoneRegions {
+		regions = append(regions, region)
+	}
+	slices.Sort(regions)
+	return regions
+}
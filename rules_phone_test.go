@@ -0,0 +1,65 @@
+package vld
+
+import (
+	"testing"
+)
+
+/**
+ * Rule: Phone
+ *
+ */
+func TestPhoneValidInput(t *testing.T) {
+	testCases := []struct {
+		region   string
+		types    []string
+		input    string
+		expected string
+	}{
+		{region: "GB", input: "020 7946 0958", expected: "+442079460958"},
+		{region: "GB", input: "+44 (0)7700 900123", expected: "+447700900123"},
+		{region: "US", input: "(202) 555-0143", expected: "+12025550143"},
+		{region: "US", input: "1-202-555-0143", expected: "+12025550143"},
+		{region: "US", input: "011 92 300 1234567", expected: "+923001234567"},
+		{region: "pk", input: "0300-1234567", expected: "+923001234567", types: []string{PHONE_MOBILE}},
+		{region: "DE", input: "0049 30 1234567", expected: "+49301234567", types: []string{PHONE_FIXED_LINE}},
+		{region: "IT", input: "06 1234 5678", expected: "+390612345678"},
+		{region: "", input: "+33 6 12 34 56 78", expected: "+33612345678"},
+	}
+
+	for _, testCase := range testCases {
+		v, err := Phone(testCase.region, testCase.types...)(testCase.input)
+		if err != nil {
+			t.Errorf(errValidFailed, testCase.input)
+			return
+		}
+
+		if v != testCase.expected {
+			t.Errorf("unexpected E.164 value %v for %s", v, testCase.input)
+			return
+		}
+	}
+}
+
+func TestPhoneInvalidInput(t *testing.T) {
+	testCases := []struct {
+		region string
+		types  []string
+		input  any
+	}{
+		{region: "GB", input: "020 7946 095"},
+		{region: "US", input: "(102) 555-0143"},
+		{region: "", input: "020 7946 0958"},    // national format without region
+		{region: "GB", input: "+999 1234 5678"}, // unknown calling code
+		{region: "PK", input: "042 1234 5678", types: []string{PHONE_MOBILE}},
+		{region: "FR", input: "06 12 34 56 78", types: []string{PHONE_FIXED_LINE}},
+		{region: "US", input: "202-555-0143 ext. 12"},
+		{region: "US", input: 2025550143},
+	}
+
+	for _, testCase := range testCases {
+		if _, err := Phone(testCase.region, testCase.types...)(testCase.input); err == nil {
+			t.Errorf("invalid input returned as valid: %v", testCase.input)
+			return
+		}
+	}
+}
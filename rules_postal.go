@@ -0,0 +1,65 @@
+package vld
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PostalCode check if the provided input is a valid postal code of the
+// provided country, e.g. "SW1A 1AA" for GB or "12345-6789" for US. When the
+// country comes from another field of the same form, pass that field's value
+// as the country (the same way Equals receives the value it compares against).
+// The returned value is normalized to upper-case with the country's usual
+// spacing, e.g. "k1a0b1" becomes "K1A 0B1". Countries without a bundled
+// format are reported as issues, see PostalCodeCountries.
+func PostalCode(country string) Rule {
+	return func(input any) (any, error) {
+		country := strings.ToUpper(strings.TrimSpace(country))
+		issue := Issue{
+			Code:    CODE_POSTAL_CODE,
+			Message: "Please provide a valid postal code",
+			Value:   country,
+		}
+
+		format, ok := postalFormats[country]
+		if !ok {
+			return nil, Issue{
+				Code:    CODE_POSTAL_CODE,
+				Message: fmt.Sprintf("Postal codes of country '%s' are not supported", country),
+				Value:   country,
+			}
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		compact := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(asString))
+		if !format.pattern.MatchString(compact) {
+			return nil, issue
+		}
+
+		position := format.separatorAt
+		if position < 0 {
+			position += len(compact)
+		}
+
+		if format.separator == "" || position <= 0 || position >= len(compact) {
+			return compact, nil
+		}
+		return compact[:position] + format.separator + compact[position:], nil
+	}
+}
+
+// PostalCodeCountries returns the ISO 3166-1 alpha-2 codes of the countries
+// whose postal code formats are bundled and can be checked with PostalCode.
+func PostalCodeCountries() []string {
+	countries := make([]string, 0, len(postalFormats))
+	for country := range postalFormats {
+		countries = append(countries, country)
+	}
+	slices.Sort(countries)
+	return countries
+}
@@ -0,0 +1,74 @@
+package vld
+
+import (
+	"testing"
+)
+
+/**
+ * Rule: PostalCode
+ *
+ */
+func TestPostalCodeValidInput(t *testing.T) {
+	testCases := []struct {
+		country  string
+		input    string
+		expected string
+	}{
+		{country: "US", input: "20500", expected: "20500"},
+		{country: "US", input: "20500-0003", expected: "20500-0003"},
+		{country: "US", input: "205000003", expected: "20500-0003"},
+		{country: "GB", input: "sw1a1aa", expected: "SW1A 1AA"},
+		{country: "GB", input: "M1 1AE", expected: "M1 1AE"},
+		{country: "gb", input: "EC1A 1BB", expected: "EC1A 1BB"},
+		{country: "CA", input: "k1a0b1", expected: "K1A 0B1"},
+		{country: "NL", input: "1012ab", expected: "1012 AB"},
+		{country: "IE", input: "a65f4e2", expected: "A65 F4E2"},
+		{country: "PL", input: "00 950", expected: "00-950"},
+		{country: "JP", input: "100-0001", expected: "100-0001"},
+		{country: "DE", input: "10117", expected: "10117"},
+	}
+
+	for _, testCase := range testCases {
+		v, err := PostalCode(testCase.country)(testCase.input)
+		if err != nil {
+			t.Errorf(errValidFailed, testCase.input)
+			return
+		}
+
+		if v != testCase.expected {
+			t.Errorf("unexpected normalized value %v for %s", v, testCase.input)
+			return
+		}
+	}
+}
+
+func TestPostalCodeInvalidInput(t *testing.T) {
+	testCases := []struct {
+		country string
+		input   any
+	}{
+		{country: "US", input: "2050"},
+		{country: "US", input: "20500-003"},
+		{country: "GB", input: "QQ1 1AA"},
+		{country: "CA", input: "D1A 0B1"},
+		{country: "NL", input: "1012 SA"},
+		{country: "DE", input: 10117},
+		{country: "", input: "10117"},
+	}
+
+	for _, testCase := range testCases {
+		if _, err := PostalCode(testCase.country)(testCase.input); err == nil {
+			t.Errorf("invalid input returned as valid: %v", testCase.input)
+			return
+		}
+	}
+}
+
+func TestPostalCodeUnsupportedCountry(t *testing.T) {
+	_, err := PostalCode("AQ")("12345")
+	issue, ok := err.(Issue)
+	if !ok || issue.Value != "AQ" {
+		t.Error("unsupported country must be reported")
+		return
+	}
+}
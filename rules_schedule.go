@@ -0,0 +1,178 @@
+package vld
+
+import (
+	"fmt"
+	"time"
+)
+
+// Schedule rules parse the formats used to describe durations and recurring
+// schedules. Go durations such as "90s" are parsed using ToDuration.
+
+// Cron check if the provided input is a valid standard 5-field cron
+// expression, e.g. "*/15 9-17 * * MON-FRI" or "@daily" (see ParseCron). The
+// returned value is a CronSchedule.
+func Cron(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_CRON,
+		Message: "Please provide a valid cron expression",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	schedule, err := ParseCron(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return schedule, nil
+}
+
+// ISO8601Duration check if the provided input is a valid ISO 8601 duration,
+// e.g. "P1DT2H" or "PT30M" (see ParseISODuration). The returned value is an
+// ISODuration.
+func ISO8601Duration(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_ISO_DURATION,
+		Message: "Please provide a valid ISO 8601 duration e.g. P1DT2H",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	duration, err := ParseISODuration(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return duration, nil
+}
+
+// RRule check if the provided input is a valid RFC 5545 recurrence rule, e.g.
+// "FREQ=WEEKLY;BYDAY=MO,WE,FR" (see ParseRRule for the supported parts). The
+// returned value is a RecurrenceRule.
+func RRule(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_RRULE,
+		Message: "Please provide a valid recurrence rule",
+	}
+
+	asString, ok := input.(string)
+	if !ok {
+		return nil, issue
+	}
+
+	rule, err := ParseRRule(asString)
+	if err != nil {
+		return nil, issue
+	}
+	return rule, nil
+}
+
+// intervals returns the intervals between the provided number of next firing
+// times of the provided input, which is either a Schedule or a duration. A
+// time.Duration is a single interval, while the length of an ISODuration
+// depends on the current time of the clock. A schedule firing fewer than
+// twice has no interval.
+func intervals(input any, occurrences int, now time.Time) ([]time.Duration, bool) {
+	switch t := input.(type) {
+	case time.Duration:
+		return []time.Duration{t}, true
+	case ISODuration:
+		return []time.Duration{t.Duration(now)}, true
+	case Schedule:
+		var gaps []time.Duration
+		previous := t.Next(now)
+		for i := 1; i < occurrences && !previous.IsZero(); i++ {
+			next := t.Next(previous)
+			if next.IsZero() {
+				break
+			}
+
+			gaps = append(gaps, next.Sub(previous))
+			previous = next
+		}
+		return gaps, true
+	}
+	return nil, false
+}
+
+// MinInterval check if the provided schedule (see Cron and RRule) fires no
+// more often than the provided interval, e.g. MinInterval(5*time.Minute, 100,
+// nil) for "runs at most every 5 minutes". The interval between each of the
+// provided number of next firing times, from the current time of the provided
+// clock, is checked, and a schedule firing fewer than twice fails. Durations
+// (time.Duration and ISODuration) are checked to be at least the provided
+// interval.
+func MinInterval(interval time.Duration, occurrences int, clock Clock) Rule {
+	return func(input any) (any, error) {
+		if occurrences < 2 {
+			return nil, ConfigError{Rule: "MinInterval", Message: fmt.Sprintf("occurrences must be at least 2, got %d", occurrences)}
+		}
+
+		issue := Issue{
+			Code:    CODE_MIN_INTERVAL,
+			Message: fmt.Sprintf("The interval must be at least %s", interval),
+			Value:   interval.String(),
+		}
+
+		gaps, ok := intervals(input, occurrences, clock.now())
+		if !ok {
+			issue.Message = "Please provide a valid schedule"
+			return nil, issue
+		}
+
+		if len(gaps) == 0 {
+			issue.Message = "The schedule must fire at least twice"
+			return nil, issue
+		}
+
+		for _, gap := range gaps {
+			if gap < interval {
+				return nil, issue
+			}
+		}
+		return input, nil
+	}
+}
+
+// MaxInterval check if the provided schedule (see Cron and RRule) fires at
+// least as often as the provided interval, e.g. MaxInterval(24*time.Hour, 30,
+// nil) for a daily backup. The interval between each of the provided number of
+// next firing times, from the current time of the provided clock, is checked.
+// A schedule firing fewer than twice fails, while one which stops firing later
+// within those occurrences passes. Durations (time.Duration and ISODuration)
+// are checked to be at most the provided interval.
+func MaxInterval(interval time.Duration, occurrences int, clock Clock) Rule {
+	return func(input any) (any, error) {
+		if occurrences < 2 {
+			return nil, ConfigError{Rule: "MaxInterval", Message: fmt.Sprintf("occurrences must be at least 2, got %d", occurrences)}
+		}
+
+		issue := Issue{
+			Code:    CODE_MAX_INTERVAL,
+			Message: fmt.Sprintf("The interval must be at most %s", interval),
+			Value:   interval.String(),
+		}
+
+		gaps, ok := intervals(input, occurrences, clock.now())
+		if !ok {
+			issue.Message = "Please provide a valid schedule"
+			return nil, issue
+		}
+
+		if len(gaps) == 0 {
+			issue.Message = "The schedule must fire at least twice"
+			return nil, issue
+		}
+
+		for _, gap := range gaps {
+			if gap > interval {
+				return nil, issue
+			}
+		}
+		return input, nil
+	}
+}
@@ -0,0 +1,145 @@
+package vld
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+/**
+ * Rule: Cron, ISO8601Duration, RRule
+ *
+ */
+func TestScheduleParsingRules(t *testing.T) {
+	testCases := []struct {
+		rule     Rule
+		valid    string
+		invalid  string
+		sentinel error
+	}{
+		{rule: Cron, valid: "*/5 * * * *", invalid: "every 5 minutes", sentinel: ErrCron},
+		{rule: ISO8601Duration, valid: "P1DT2H", invalid: "1 day", sentinel: ErrISODuration},
+		{rule: RRule, valid: "RRULE:FREQ=WEEKLY;BYDAY=MO", invalid: "FREQ=FORTNIGHTLY", sentinel: ErrRRule},
+	}
+
+	for _, testCase := range testCases {
+		v, err := testCase.rule(testCase.valid)
+		if err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+
+		if _, ok := v.(Schedule); !ok {
+			if _, ok := v.(ISODuration); !ok {
+				t.Error(errInvalidReturnType)
+				return
+			}
+		}
+
+		if _, err := testCase.rule(testCase.invalid); !errors.Is(err, testCase.sentinel) {
+			t.Error(errInvalidPassed)
+			return
+		}
+
+		if _, err := testCase.rule(42); err == nil {
+			t.Error(errInvalidTypePassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: MinInterval, MaxInterval
+ *
+ */
+func TestMinInterval(t *testing.T) {
+	clock := fixedClock(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	rule := MinInterval(5*time.Minute, 50, clock)
+
+	for _, input := range []string{"*/5 * * * *", "0 * * * *", "0,30 9 * * MON"} {
+		schedule, _ := Cron(input)
+		if _, err := rule(schedule); err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+	}
+
+	for _, input := range []string{"* * * * *", "0,1 9 * * *"} {
+		schedule, _ := Cron(input)
+		if _, err := rule(schedule); !errors.Is(err, ErrMinInterval) {
+			t.Errorf("%s: %s", input, errInvalidPassed)
+			return
+		}
+	}
+
+	schedule, _ := RRule("FREQ=MINUTELY;INTERVAL=2")
+	if _, err := rule(schedule); !errors.Is(err, ErrMinInterval) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := rule(90 * time.Second); !errors.Is(err, ErrMinInterval) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := rule("*/5 * * * *"); err == nil {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+
+	once, _ := RRule("DTSTART:20240401T090000Z\nRRULE:FREQ=DAILY;COUNT=1")
+	if _, err := rule(once); !errors.Is(err, ErrMinInterval) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	var errConfig ConfigError
+	if _, err := MinInterval(time.Minute, 1, clock)(schedule); !errors.As(err, &errConfig) {
+		t.Error("less than 2 occurrences must be reported as a ConfigError")
+		return
+	}
+}
+
+func TestMaxInterval(t *testing.T) {
+	// February 2024 has 29 days.
+	clock := fixedClock(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	rule := MaxInterval(30*24*time.Hour, 3, clock)
+
+	month, _ := ISO8601Duration("P1M")
+	if _, err := rule(month); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	schedule, _ := Cron("0 0 1 * *")
+	if _, err := rule(schedule); !errors.Is(err, ErrMaxInterval) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	daily, _ := Cron("@daily")
+	if _, err := MaxInterval(24*time.Hour, 10, clock)(daily); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	once, _ := RRule("DTSTART:20240201T090000Z\nRRULE:FREQ=DAILY;COUNT=1")
+	if _, err := rule(once); !errors.Is(err, ErrMaxInterval) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	// durations overflowing a time.Duration used to wrap to negative ones.
+	for _, input := range []string{"PT3000000H", "PT9999999999999S"} {
+		duration, err := ISO8601Duration(input)
+		if err == nil {
+			_, err = MaxInterval(24*time.Hour, 2, clock)(duration)
+		}
+
+		if err == nil {
+			t.Errorf("%s: %s", input, errInvalidPassed)
+			return
+		}
+	}
+}
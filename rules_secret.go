@@ -0,0 +1,81 @@
+package vld
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SecretDetector detects a kind of secret, e.g. an AWS access key, within a
+// string.
+type SecretDetector struct {
+	// Name describes the kind of secret e.g. "AWS access key". It's reported
+	// in the issue instead of the secret itself.
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// DefaultSecretDetectors detect commonly leaked credentials: cloud and SaaS
+// API keys, private keys, JWTs, and values assigned to keys named like a
+// secret e.g. "api_key=...".
+var DefaultSecretDetectors = []SecretDetector{
+	{"AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`)},
+	{"AWS secret key", regexp.MustCompile(`(?i)aws.{0,20}?(?:secret|key).{0,20}?[:=]\s*["']?[A-Za-z0-9/+=]{40}\b`)},
+	{"private key", regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"Stripe key", regexp.MustCompile(`\b(?:sk|rk)_(?:live|test)_[A-Za-z0-9]{16,}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"JWT", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+	{"API key", regexp.MustCompile(`(?i)\b(?:api[_-]?key|secret|access[_-]?token|auth[_-]?token|password)["']?\s*[:=]\s*["']?[A-Za-z0-9_\-/+=.]{16,}`)},
+}
+
+// NoSecrets check if the provided input is a string which doesn't seem to
+// contain a secret, such as an API key pasted into a free-text field, using
+// DefaultSecretDetectors. The issue lists the kinds of secrets found, never
+// the secrets themselves.
+func NoSecrets(input any) (any, error) {
+	return NoSecretsMatching(DefaultSecretDetectors...)(input)
+}
+
+// NoSecretsMatching check if the provided input is a string in which none of
+// the provided detectors find a secret, e.g.
+// NoSecretsMatching(append(DefaultSecretDetectors, internalTokenDetector)...).
+func NoSecretsMatching(detectors ...SecretDetector) Rule {
+	var errConfig error
+	for _, detector := range detectors {
+		if detector.Pattern == nil {
+			errConfig = ConfigError{Rule: "NoSecretsMatching", Message: fmt.Sprintf("detector '%s' has no pattern", detector.Name)}
+		}
+	}
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		issue := Issue{
+			Code:    CODE_SECRET,
+			Message: "The value cannot contain secrets",
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		var found []string
+		for _, detector := range detectors {
+			if detector.Pattern.MatchString(asString) {
+				found = append(found, detector.Name)
+			}
+		}
+
+		if len(found) != 0 {
+			issue.Message = fmt.Sprintf("The value seems to contain a secret (%s), please remove it", strings.Join(found, ", "))
+			issue.Value = found
+			return nil, issue
+		}
+		return asString, nil
+	}
+}
@@ -1073,6 +1073,24 @@ func TestLatitudeInvalidInput(t *testing.T) {
 	}
 }
 
+func TestLatitudeFloat64Input(t *testing.T) {
+	result, err := Latitude(float64(31.475240))
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, ok := result.(float64); !ok {
+		t.Error(errInvalidReturnType)
+		return
+	}
+
+	if _, err := Latitude(float64(-91)); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
 func TestLatitudeInvalidInputType(t *testing.T) {
 	if _, err := Latitude("abc"); err == nil {
 		t.Error(errInvalidTypePassed)
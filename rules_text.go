@@ -0,0 +1,342 @@
+package vld
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// Units in which the length of a string is measured. Runes are Unicode code
+// points, while grapheme clusters are the characters perceived by users, e.g.
+// "é" written as "e" followed by a combining accent, or a flag emoji made of
+// two code points, is a single grapheme cluster.
+const (
+	LENGTH_BYTES     = "bytes"
+	LENGTH_RUNES     = "runes"
+	LENGTH_GRAPHEMES = "graphemes"
+)
+
+// stringLength returns the length of the provided string in the provided
+// unit, and false when the unit is unknown.
+func stringLength(input string, unit string) (int, bool) {
+	switch unit {
+	case LENGTH_BYTES:
+		return len(input), true
+	case LENGTH_RUNES:
+		return utf8.RuneCountInString(input), true
+	case LENGTH_GRAPHEMES:
+		return uniseg.GraphemeClusterCount(input), true
+	}
+	return 0, false
+}
+
+func lengthUnitError(rule string, unit string) error {
+	return ConfigError{Rule: rule, Message: fmt.Sprintf("unknown length unit '%s'", unit)}
+}
+
+// LengthIn check if the provided input is a string and its length, in the
+// provided unit (e.g. LENGTH_GRAPHEMES), is equal to the provided length.
+func LengthIn(unit string, length int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_LENGTH,
+			Message: fmt.Sprintf("The value must be %d characters in length", length),
+			Value:   length,
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		actual, known := stringLength(asString, unit)
+		if !known {
+			return nil, lengthUnitError("LengthIn", unit)
+		}
+
+		if actual != length {
+			return nil, issue
+		}
+		return asString, nil
+	}
+}
+
+// MinLength check if the provided input is a string and its length, in the
+// provided unit (e.g. LENGTH_GRAPHEMES), is more than or equal to the provided
+// length.
+func MinLength(unit string, length int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_MIN,
+			Message: fmt.Sprintf("The length must be more than %d characters", length),
+			Value:   length,
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		actual, known := stringLength(asString, unit)
+		if !known {
+			return nil, lengthUnitError("MinLength", unit)
+		}
+
+		if actual < length {
+			return nil, issue
+		}
+		return asString, nil
+	}
+}
+
+// MaxLength check if the provided input is a string and its length, in the
+// provided unit (e.g. LENGTH_BYTES for a database column limit), is less than
+// or equal to the provided length.
+func MaxLength(unit string, length int) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    CODE_MAX,
+			Message: fmt.Sprintf("The length must be less than %d characters", length),
+			Value:   length,
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return nil, issue
+		}
+
+		actual, known := stringLength(asString, unit)
+		if !known {
+			return nil, lengthUnitError("MaxLength", unit)
+		}
+
+		if actual > length {
+			return nil, issue
+		}
+		return asString, nil
+	}
+}
+
+// characterClass returns a rule which checks that every rune of a non-empty
+// string is valid.
+func characterClass(code string, message string, valid func(rune) bool) Rule {
+	return func(input any) (any, error) {
+		issue := Issue{
+			Code:    code,
+			Message: message,
+		}
+
+		asString, ok := input.(string)
+		if !ok || asString == "" || !utf8.ValidString(asString) {
+			return nil, issue
+		}
+
+		for _, r := range asString {
+			if !valid(r) {
+				return nil, issue
+			}
+		}
+		return asString, nil
+	}
+}
+
+// Alpha check if the provided input is a non-empty string of letters, in any
+// script. Combining marks, such as accents, are allowed as well.
+func Alpha(input any) (any, error) {
+	return characterClass(CODE_ALPHA, "Please provide letters only", func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsMark(r)
+	})(input)
+}
+
+// Alphanumeric check if the provided input is a non-empty string of letters
+// and digits, in any script. Combining marks, such as accents, are allowed as
+// well.
+func Alphanumeric(input any) (any, error) {
+	return characterClass(CODE_ALPHANUMERIC, "Please provide letters and digits only", func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+	})(input)
+}
+
+// ASCII check if the provided input is a non-empty string of ASCII characters.
+func ASCII(input any) (any, error) {
+	return characterClass(CODE_ASCII, "Please provide ASCII characters only", func(r rune) bool {
+		return r <= unicode.MaxASCII
+	})(input)
+}
+
+// PrintableASCII check if the provided input is a non-empty string of
+// printable ASCII characters, i.e. letters, digits, punctuation and spaces.
+func PrintableASCII(input any) (any, error) {
+	return characterClass(CODE_PRINTABLE_ASCII, "Please provide printable ASCII characters only", func(r rune) bool {
+		return r >= ' ' && r <= '~'
+	})(input)
+}
+
+// NoControlChars check if the provided input is a string without control
+// characters, such as NUL or escape. Tabs and line breaks are allowed. See
+// StripControlChars to remove them instead.
+func NoControlChars(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_CONTROL_CHARS,
+		Message: "The value cannot contain control characters",
+	}
+
+	asString, ok := input.(string)
+	if !ok || !utf8.ValidString(asString) {
+		return nil, issue
+	}
+
+	for _, r := range asString {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return nil, issue
+		}
+	}
+	return asString, nil
+}
+
+// unicodeScript is a named Unicode script of unicode.Scripts.
+type unicodeScript struct {
+	name  string
+	table *unicode.RangeTable
+}
+
+// unicodeScripts holds unicode.Scripts, without the Common and Inherited
+// scripts, in a fixed order so they aren't looked up by ranging over the map
+// for every rune.
+var unicodeScripts = func() []unicodeScript {
+	var scripts []unicodeScript
+	for name, table := range unicode.Scripts {
+		if name != "Common" && name != "Inherited" {
+			scripts = append(scripts, unicodeScript{name: name, table: table})
+		}
+	}
+	slices.SortFunc(scripts, func(a, b unicodeScript) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return scripts
+}()
+
+// scriptOf returns the Unicode script of the provided rune e.g. "Latin", or
+// false for the runes shared between scripts, such as digits, punctuation and
+// combining marks (the Common and Inherited scripts).
+func scriptOf(r rune) (unicodeScript, bool) {
+	if unicode.In(r, unicode.Common, unicode.Inherited) {
+		return unicodeScript{}, false
+	}
+
+	for _, script := range unicodeScripts {
+		if unicode.Is(script.table, r) {
+			return script, true
+		}
+	}
+	return unicodeScript{}, false
+}
+
+// scriptsOf returns the sorted scripts used in the provided string, see
+// scriptOf.
+func scriptsOf(input string) []string {
+	var scripts []string
+	// the script of the previous letter is checked first, as text is mostly
+	// written in a single script.
+	var last *unicode.RangeTable
+	for _, r := range input {
+		if last != nil && unicode.Is(last, r) {
+			continue
+		}
+
+		if script, ok := scriptOf(r); ok {
+			last = script.table
+			if !slices.Contains(scripts, script.name) {
+				scripts = append(scripts, script.name)
+			}
+		}
+	}
+	slices.Sort(scripts)
+	return scripts
+}
+
+// Script check if the provided input is a string whose letters are all in the
+// provided Unicode scripts, e.g. Script("Latin") or Script("Latin", "Greek").
+// Script names are the ones of unicode.Scripts. Digits, punctuation, spaces
+// and combining marks are allowed with every script.
+func Script(scripts ...string) Rule {
+	var errConfig error
+	for _, script := range scripts {
+		if _, ok := unicode.Scripts[script]; !ok {
+			errConfig = ConfigError{Rule: "Script", Message: fmt.Sprintf("unknown script '%s'", script)}
+		}
+	}
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		issue := Issue{
+			Code:    CODE_SCRIPT,
+			Message: fmt.Sprintf("Please provide %s characters only", strings.Join(scripts, ", ")),
+			Value:   scripts,
+		}
+
+		asString, ok := input.(string)
+		if !ok || !utf8.ValidString(asString) {
+			return nil, issue
+		}
+
+		for _, script := range scriptsOf(asString) {
+			if !slices.Contains(scripts, script) {
+				return nil, issue
+			}
+		}
+		return asString, nil
+	}
+}
+
+// allowedScriptMixes are the combinations of scripts commonly used together
+// in a single word, following the "highly restrictive" level of Unicode
+// Technical Standard #39.
+var allowedScriptMixes = [][]string{
+	{"Han", "Hiragana", "Katakana", "Latin"},
+	{"Bopomofo", "Han", "Latin"},
+	{"Han", "Hangul", "Latin"},
+}
+
+// NoMixedScripts check if the provided input is a string which doesn't mix
+// letters of different Unicode scripts, e.g. a Latin "a" and a Cyrillic "а",
+// which is a common trick for impersonating other users with look-alike
+// (homoglyph) names. The combinations used to write Japanese, Chinese and
+// Korean are allowed.
+func NoMixedScripts(input any) (any, error) {
+	issue := Issue{
+		Code:    CODE_MIXED_SCRIPTS,
+		Message: "The value cannot mix characters of different scripts",
+	}
+
+	asString, ok := input.(string)
+	if !ok || !utf8.ValidString(asString) {
+		return nil, issue
+	}
+
+	scripts := scriptsOf(asString)
+	if len(scripts) <= 1 {
+		return asString, nil
+	}
+
+	for _, mix := range allowedScriptMixes {
+		allowed := true
+		for _, script := range scripts {
+			allowed = allowed && slices.Contains(mix, script)
+		}
+
+		if allowed {
+			return asString, nil
+		}
+	}
+
+	issue.Value = scripts
+	return nil, issue
+}
@@ -0,0 +1,166 @@
+package vld
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLengthCountsRunes(t *testing.T) {
+	if _, err := Length(4)("José"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := Max(3)("日本語"); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := Min(2)("日"); !errors.Is(err, ErrMin) {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
+
+/**
+ * Rule: LengthIn, MinLength, MaxLength
+ *
+ */
+func TestLengthIn(t *testing.T) {
+	// "e" followed by a combining accent, and a flag made of two code points.
+	input := "José 🇵🇰"
+
+	testCases := map[string]int{LENGTH_BYTES: 15, LENGTH_RUNES: 8, LENGTH_GRAPHEMES: 6}
+	for unit, length := range testCases {
+		if _, err := LengthIn(unit, length)(input); err != nil {
+			t.Errorf("%s: %s", unit, err.Error())
+			return
+		}
+
+		if _, err := LengthIn(unit, length+1)(input); !errors.Is(err, ErrLength) {
+			t.Errorf("%s: %s", unit, errInvalidPassed)
+			return
+		}
+	}
+
+	var errConfig ConfigError
+	if _, err := LengthIn("words", 2)(input); !errors.As(err, &errConfig) {
+		t.Error("unknown unit must be reported as a ConfigError")
+		return
+	}
+}
+
+func TestMinMaxLength(t *testing.T) {
+	family := "👨‍👩‍👧"
+	if _, err := MaxLength(LENGTH_GRAPHEMES, 1)(family); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := MaxLength(LENGTH_RUNES, 1)(family); !errors.Is(err, ErrMax) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := MinLength(LENGTH_BYTES, 10)(family); err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if _, err := MinLength(LENGTH_GRAPHEMES, 2)(family); !errors.Is(err, ErrMin) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := MinLength(LENGTH_GRAPHEMES, 0)(42); err == nil {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+}
+
+/**
+ * Rule: Alpha, Alphanumeric, ASCII, PrintableASCII, NoControlChars
+ *
+ */
+func TestCharacterClasses(t *testing.T) {
+	testCases := []struct {
+		rule     Rule
+		valid    []string
+		invalid  []string
+		sentinel error
+	}{
+		{rule: Alpha, valid: []string{"José", "José", "Ελένη"}, invalid: []string{"", "John Doe", "R2D2"}, sentinel: ErrAlpha},
+		{rule: Alphanumeric, valid: []string{"R2D2", "Zoë1"}, invalid: []string{"", "R2-D2", "a_b"}, sentinel: ErrAlphanumeric},
+		{rule: ASCII, valid: []string{"John\tDoe", "a_b"}, invalid: []string{"", "José"}, sentinel: ErrASCII},
+		{rule: PrintableASCII, valid: []string{"John Doe!"}, invalid: []string{"", "John\tDoe", "José"}, sentinel: ErrPrintableASCII},
+		{rule: NoControlChars, valid: []string{"", "John\nDoe\t", "José"}, invalid: []string{"John\x00", "\x1b[31m", "\u0085"}, sentinel: ErrControlChars},
+	}
+
+	for _, testCase := range testCases {
+		for _, input := range testCase.valid {
+			if _, err := testCase.rule(input); err != nil {
+				t.Errorf("%q: %s", input, err.Error())
+				return
+			}
+		}
+
+		for _, input := range testCase.invalid {
+			if _, err := testCase.rule(input); !errors.Is(err, testCase.sentinel) {
+				t.Errorf("%q: %s", input, errInvalidPassed)
+				return
+			}
+		}
+
+		if _, err := testCase.rule(42); err == nil {
+			t.Error(errInvalidTypePassed)
+			return
+		}
+	}
+}
+
+/**
+ * Rule: Script, NoMixedScripts
+ *
+ */
+func TestScript(t *testing.T) {
+	rule := Script("Latin")
+	for _, input := range []string{"José", "john.doe_42", ""} {
+		if _, err := rule(input); err != nil {
+			t.Errorf(errValidFailed, err.Error())
+			return
+		}
+	}
+
+	// the "а" is Cyrillic.
+	if _, err := rule("pаypal"); !errors.Is(err, ErrScript) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	var errConfig ConfigError
+	if _, err := Script("Klingon")("a"); !errors.As(err, &errConfig) {
+		t.Error("unknown script must be reported as a ConfigError")
+		return
+	}
+}
+
+func TestNoMixedScripts(t *testing.T) {
+	for _, input := range []string{"paypal", "пейпал", "東京tower", "ひらがなカタカナ漢字", "user_42"} {
+		if _, err := NoMixedScripts(input); err != nil {
+			t.Errorf("%q: %s", input, err.Error())
+			return
+		}
+	}
+
+	_, err := NoMixedScripts("pаypal")
+	issues := Issues(err)
+	if len(issues) != 1 || issues[0].Code != CODE_MIXED_SCRIPTS {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := NoMixedScripts("Ελλάδαx"); err == nil {
+		t.Error(errInvalidPassed)
+		return
+	}
+}
@@ -0,0 +1,131 @@
+package vld
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Transformers are rules which clean up the input instead of checking it. They
+// never report issues for strings, and pass any other input through unchanged
+// so that the next rule in the chain can report it.
+
+const (
+	NORMALIZATION_NFC  = "NFC"
+	NORMALIZATION_NFD  = "NFD"
+	NORMALIZATION_NFKC = "NFKC"
+	NORMALIZATION_NFKD = "NFKD"
+)
+
+// TrimSpace removes leading and trailing white space from the provided input.
+func TrimSpace(input any) (any, error) {
+	asString, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	return strings.TrimSpace(asString), nil
+}
+
+// Lowercase converts the provided input to lower-case.
+func Lowercase(input any) (any, error) {
+	asString, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	return strings.ToLower(asString), nil
+}
+
+// Uppercase converts the provided input to upper-case.
+func Uppercase(input any) (any, error) {
+	asString, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	return strings.ToUpper(asString), nil
+}
+
+// NormalizeUnicode converts the provided input to the provided Unicode
+// normalization form, e.g. NORMALIZATION_NFC. NFC is recommended for storing
+// user input, while NFKC also folds compatibility characters such as "ﬁ" and
+// full-width letters, which is useful for identifiers.
+func NormalizeUnicode(form string) Rule {
+	forms := map[string]norm.Form{
+		NORMALIZATION_NFC:  norm.NFC,
+		NORMALIZATION_NFD:  norm.NFD,
+		NORMALIZATION_NFKC: norm.NFKC,
+		NORMALIZATION_NFKD: norm.NFKD,
+	}
+	normForm, known := forms[form]
+
+	return func(input any) (any, error) {
+		if !known {
+			return nil, ConfigError{Rule: "NormalizeUnicode", Message: fmt.Sprintf("unknown normalization form '%s'", form)}
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return input, nil
+		}
+		return normForm.String(asString), nil
+	}
+}
+
+// CollapseWhitespace replaces every run of white space in the provided input
+// with a single space, and removes leading and trailing white space.
+func CollapseWhitespace(input any) (any, error) {
+	asString, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+	return strings.Join(strings.Fields(asString), " "), nil
+}
+
+// StripControlChars removes control characters, such as NUL or escape, from
+// the provided input. Tabs and line breaks are kept.
+func StripControlChars(input any) (any, error) {
+	asString, ok := input.(string)
+	if !ok {
+		return input, nil
+	}
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, asString), nil
+}
+
+// Default replaces a missing input, i.e. nil or an empty string, with the
+// provided value.
+func Default(value any) Rule {
+	return func(input any) (any, error) {
+		if input == nil || input == "" {
+			return value, nil
+		}
+		return input, nil
+	}
+}
+
+// Truncate shortens the provided input to at most the provided number of
+// characters (runes).
+func Truncate(length int) Rule {
+	return func(input any) (any, error) {
+		if length < 0 {
+			return nil, ConfigError{Rule: "Truncate", Message: "length cannot be negative"}
+		}
+
+		asString, ok := input.(string)
+		if !ok {
+			return input, nil
+		}
+
+		runes := []rune(asString)
+		if len(runes) <= length {
+			return asString, nil
+		}
+		return string(runes[:length]), nil
+	}
+}
@@ -0,0 +1,104 @@
+package vld
+
+import (
+	"testing"
+)
+
+/**
+ * Rule: TrimSpace, Lowercase, Uppercase, CollapseWhitespace, StripControlChars
+ *
+ */
+func TestStringTransformers(t *testing.T) {
+	testCases := []struct {
+		rule     Rule
+		input    string
+		expected string
+	}{
+		{rule: TrimSpace, input: "  admin@site.com \n", expected: "admin@site.com"},
+		{rule: Lowercase, input: "Admin@Site.COM", expected: "admin@site.com"},
+		{rule: Uppercase, input: "pk", expected: "PK"},
+		{rule: CollapseWhitespace, input: "  John \t\n  Doe  ", expected: "John Doe"},
+		{rule: StripControlChars, input: "John\x00 Doe\x1b[0m\n", expected: "John Doe[0m\n"},
+	}
+
+	for _, testCase := range testCases {
+		v, err := testCase.rule(testCase.input)
+		if err != nil {
+			t.Errorf(errValidFailed, testCase.input)
+			return
+		}
+
+		if v != testCase.expected {
+			t.Errorf("unexpected output %q for %q", v, testCase.input)
+			return
+		}
+	}
+}
+
+func TestTransformersPassThroughOtherTypes(t *testing.T) {
+	for _, rule := range []Rule{TrimSpace, Lowercase, Uppercase, CollapseWhitespace, StripControlChars, Truncate(2)} {
+		v, err := rule(42)
+		if err != nil || v != 42 {
+			t.Error("non-string input must be passed through unchanged")
+			return
+		}
+	}
+}
+
+/**
+ * Rule: NormalizeUnicode
+ *
+ */
+func TestNormalizeUnicode(t *testing.T) {
+	decomposed := "Jose\u0301"
+	v, err := NormalizeUnicode(NORMALIZATION_NFC)(decomposed)
+	if err != nil || v != "José" {
+		t.Errorf("unexpected NFC output %q", v)
+		return
+	}
+
+	v, err = NormalizeUnicode(NORMALIZATION_NFKC)("ﬁle Ａ")
+	if err != nil || v != "file A" {
+		t.Errorf("unexpected NFKC output %q", v)
+		return
+	}
+
+	if _, err := NormalizeUnicode("NFX")("abc"); err == nil {
+		t.Error("unknown normalization form must be reported")
+		return
+	}
+}
+
+/**
+ * Rule: Default
+ *
+ */
+func TestDefault(t *testing.T) {
+	for _, input := range []any{nil, ""} {
+		if v, _ := Default("en")(input); v != "en" {
+			t.Errorf("default not applied to %v", input)
+			return
+		}
+	}
+
+	if v, _ := Default("en")("de"); v != "de" {
+		t.Error("provided input must not be replaced")
+		return
+	}
+}
+
+/**
+ * Rule: Truncate
+ *
+ */
+func TestTruncate(t *testing.T) {
+	if v, _ := Truncate(4)("José Doe"); v != "José" {
+		t.Errorf("unexpected output %q", v)
+		return
+	}
+
+	if v, _ := Truncate(10)("short"); v != "short" {
+		t.Errorf("unexpected output %q", v)
+		return
+	}
+}
@@ -0,0 +1,70 @@
+package vld
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Union check if the provided map is one of the provided variants, e.g. an
+// event payload which is either {"type": "card", ...} or
+// {"type": "bank_transfer", ...}. The variant is chosen using the value of the
+// discriminator key, and the map is then checked using the rule of that
+// variant, usually a Map rule. The issues of the variant are returned as they
+// are, keeping their paths e.g. "payment.card_number". Note that the variant
+// rules receive the whole map, which is why strict Map rules must list the
+// discriminator key as well.
+func Union(discriminator string, variants map[string]Rule) Rule {
+	var errConfig error
+	types := make([]string, 0, len(variants))
+	for variantType := range variants {
+		types = append(types, variantType)
+	}
+	slices.Sort(types)
+
+	// the variants are checked in sorted order, so the same variant is always
+	// reported when several have no rule.
+	for _, variantType := range types {
+		if variants[variantType] == nil {
+			errConfig = ConfigError{Rule: "Union", Message: fmt.Sprintf("no rule provided for variant '%s'", variantType)}
+			break
+		}
+	}
+
+	if len(variants) == 0 {
+		errConfig = ConfigError{Rule: "Union", Message: "no variants provided"}
+	}
+
+	return func(input any) (any, error) {
+		if errConfig != nil {
+			return nil, errConfig
+		}
+
+		value, ok := stringMap(input)
+		if !ok {
+			return nil, mapIssue(CODE_UNION)
+		}
+
+		entry, exists := mapEntry(value, discriminator)
+		if !exists {
+			return nil, Issue{
+				Code:    CODE_REQUIRED_KEY,
+				Message: "This field is required",
+				Value:   discriminator,
+				Path:    keyPath(discriminator),
+			}
+		}
+
+		variantType, _ := entry.(string)
+		variant, known := variants[variantType]
+		if !known {
+			return nil, Issue{
+				Code:    CODE_UNION,
+				Message: fmt.Sprintf("The input must match values %s", strings.Join(types, ", ")),
+				Value:   types,
+				Path:    keyPath(discriminator),
+			}
+		}
+		return variant(input)
+	}
+}
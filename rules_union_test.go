@@ -0,0 +1,111 @@
+package vld
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func paymentUnion() Rule {
+	return Union("type", map[string]Rule{
+		"card": Map(UNKNOWN_KEYS_STRICT,
+			RequiredKey("type"),
+			RequiredKey("card_number", CreditCard),
+		),
+		"bank_transfer": Map(UNKNOWN_KEYS_STRICT,
+			RequiredKey("type"),
+			RequiredKey("iban", IBAN),
+			OptionalKey("reference", TrimSpace),
+		),
+	})
+}
+
+/**
+ * Rule: Union
+ *
+ */
+func TestUnion(t *testing.T) {
+	rule := paymentUnion()
+
+	v, err := rule(map[string]any{"type": "bank_transfer", "iban": "DE89 3704 0044 0532 0130 00", "reference": " INV-1 "})
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	expected := map[string]any{"type": "bank_transfer", "iban": "DE89370400440532013000", "reference": "INV-1"}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("unexpected output %v", v)
+		return
+	}
+
+	_, err = rule(map[string]any{"type": "card", "card_number": "4111 1111 1111 1112"})
+	issues := Issues(err)
+	if len(issues) != 1 || issues[0].Code != CODE_CREDIT_CARD || issues[0].Path != ".card_number" {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+}
+
+func TestUnionUnknownDiscriminator(t *testing.T) {
+	rule := paymentUnion()
+
+	_, err := rule(map[string]any{"type": "cash"})
+	issues := Issues(err)
+	if len(issues) != 1 || issues[0].Code != CODE_UNION || issues[0].Path != ".type" {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+
+	if !reflect.DeepEqual(issues[0].Value, []string{"bank_transfer", "card"}) {
+		t.Errorf("unexpected allowed types %v", issues[0].Value)
+		return
+	}
+
+	if _, err := rule(map[string]any{"card_number": "4111111111111111"}); !errors.Is(err, ErrRequiredKey) {
+		t.Error(errInvalidPassed)
+		return
+	}
+
+	if _, err := rule("card"); !errors.Is(err, ErrUnion) {
+		t.Error(errInvalidTypePassed)
+		return
+	}
+}
+
+func TestUnionConfigError(t *testing.T) {
+	err := Check([]Validation{
+		{Tag: "payment", Rules: []Rule{Union("type", map[string]Rule{"card": nil})}},
+	})
+
+	var errConfig ConfigError
+	if !errors.As(err, &errConfig) || errConfig.Tag != "payment" {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+
+	for range 10 {
+		_, err := Union("type", map[string]Rule{"wire": nil, "bank": nil, "card": nil})(nil)
+		if !errors.As(err, &errConfig) || !strings.Contains(errConfig.Message, "'bank'") {
+			t.Errorf("unexpected error %v", err)
+			return
+		}
+	}
+}
+
+func TestValidateUnionPaths(t *testing.T) {
+	err := Validate([]Validation{
+		{
+			Tag:   "payment",
+			Data:  map[string]any{"type": "bank_transfer", "iban": "DE00"},
+			Rules: []Rule{paymentUnion()},
+		},
+	})
+
+	var errValidation ValidationErrors
+	if !errors.As(err, &errValidation) || errValidation.Errors["payment.iban"].Code != CODE_IBAN {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+}
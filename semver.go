@@ -0,0 +1,283 @@
+package vld
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var semverPattern = regexp.MustCompile(PATTERN_SEMVER)
+
+// Version is a parsed semantic version, see https://semver.org.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      []string
+}
+
+// ParseVersion parses a semantic version string. A leading "v" is accepted.
+func ParseVersion(input string) (Version, error) {
+	matches := semverPattern.FindStringSubmatch(strings.TrimPrefix(input, "v"))
+	if matches == nil {
+		return Version{}, fmt.Errorf("invalid semantic version '%s'", input)
+	}
+
+	var version Version
+	var err error
+	if version.Major, err = strconv.ParseUint(matches[1], 10, 64); err != nil {
+		return Version{}, err
+	}
+	if version.Minor, err = strconv.ParseUint(matches[2], 10, 64); err != nil {
+		return Version{}, err
+	}
+	if version.Patch, err = strconv.ParseUint(matches[3], 10, 64); err != nil {
+		return Version{}, err
+	}
+
+	if matches[4] != "" {
+		version.Prerelease = strings.Split(matches[4], ".")
+	}
+	if matches[5] != "" {
+		version.Build = strings.Split(matches[5], ".")
+	}
+	return version, nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) != 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) != 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Compare returns -1, 0 or 1 depending on whether v has lower, equal or higher
+// precedence than other. Build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	if c := compareUint(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	// a version without pre-release identifiers has higher precedence.
+	switch {
+	case len(v.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(other.Prerelease); i++ {
+		a, b := v.Prerelease[i], other.Prerelease[i]
+		aNum, errA := strconv.ParseUint(a, 10, 64)
+		bNum, errB := strconv.ParseUint(b, 10, 64)
+
+		switch {
+		case errA == nil && errB == nil:
+			if c := compareUint(aNum, bNum); c != 0 {
+				return c
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(a, b); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareUint(uint64(len(v.Prerelease)), uint64(len(other.Prerelease)))
+}
+
+type versionComparator struct {
+	operator string
+	version  Version
+}
+
+func (c versionComparator) check(v Version) bool {
+	result := v.Compare(c.version)
+	switch c.operator {
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	}
+	return result == 0
+}
+
+// Constraint is a parsed semantic version range, see ParseConstraint.
+type Constraint struct {
+	// alternatives are joined with "||", the comparators of each alternative
+	// must all be satisfied.
+	alternatives [][]versionComparator
+}
+
+// Check reports whether the provided version satisfies the constraint.
+func (c Constraint) Check(v Version) bool {
+	for _, comparators := range c.alternatives {
+		satisfied := true
+		for _, comparator := range comparators {
+			if !comparator.check(v) {
+				satisfied = false
+				break
+			}
+		}
+
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePartialVersion parses versions which may omit the minor and patch
+// numbers, e.g. "1" or "1.2". The number of provided parts is returned
+// alongside the version.
+func parsePartialVersion(input string) (Version, int, error) {
+	input = strings.TrimPrefix(input, "v")
+	parts := strings.SplitN(input, ".", 3)
+	if len(parts) == 3 {
+		version, err := ParseVersion(input)
+		return version, 3, err
+	}
+
+	numbers := make([]uint64, 2)
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return Version{}, 0, fmt.Errorf("invalid version '%s' in constraint", input)
+		}
+		numbers[i] = n
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1]}, len(parts), nil
+}
+
+// parseComparator expands a single constraint term into the comparators it
+// stands for. Partial versions are expanded so that "<2" means "<2.0.0",
+// "1.2" means ">=1.2.0 <1.3.0", "~1.2.3" means ">=1.2.3 <1.3.0" and "^1.2.3"
+// means ">=1.2.3 <2.0.0".
+func parseComparator(term string) ([]versionComparator, error) {
+	operator := ""
+	for _, op := range []string{">=", "<=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(term, op) {
+			operator = op
+			break
+		}
+	}
+
+	version, parts, err := parsePartialVersion(strings.TrimSpace(term[len(operator):]))
+	if err != nil {
+		return nil, err
+	}
+
+	// upper is the exclusive upper bound of a partial or tilde / caret range.
+	var upper Version
+	nextMajor := Version{Major: version.Major + 1}
+	nextMinor := Version{Major: version.Major, Minor: version.Minor + 1}
+	nextPatch := Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch + 1}
+
+	switch {
+	case operator == "^" && (version.Major != 0 || parts == 1):
+		upper = nextMajor
+	case operator == "^" && (version.Minor != 0 || parts == 2):
+		upper = nextMinor
+	case operator == "^":
+		upper = nextPatch
+	case operator == "~" && parts == 1:
+		upper = nextMajor
+	case operator == "~":
+		upper = nextMinor
+	case parts == 1:
+		upper = nextMajor
+	case parts == 2:
+		upper = nextMinor
+	default:
+		upper = nextPatch
+	}
+
+	switch operator {
+	case ">", "<=":
+		if parts < 3 {
+			// ">1.2" excludes all of 1.2.x, "<=1.2" includes all of 1.2.x.
+			flipped := map[string]string{">": ">=", "<=": "<"}[operator]
+			return []versionComparator{{operator: flipped, version: upper}}, nil
+		}
+		return []versionComparator{{operator: operator, version: version}}, nil
+	case ">=", "<":
+		return []versionComparator{{operator: operator, version: version}}, nil
+	case "=", "":
+		if parts == 3 {
+			return []versionComparator{{operator: "=", version: version}}, nil
+		}
+	}
+
+	return []versionComparator{
+		{operator: ">=", version: version},
+		{operator: "<", version: upper},
+	}, nil
+}
+
+// ParseConstraint parses a semantic version range. Comparators separated by
+// spaces must all be satisfied, while alternatives are separated by "||".
+// Supported operators are =, >, >=, <, <=, ~ and ^. Versions may omit the
+// minor and patch numbers, e.g. ">=1.2 <2".
+func ParseConstraint(input string) (Constraint, error) {
+	var constraint Constraint
+	for _, alternative := range strings.Split(input, "||") {
+		terms := strings.Fields(alternative)
+		if len(terms) == 0 {
+			return Constraint{}, errors.New("empty version constraint")
+		}
+
+		var comparators []versionComparator
+		for i := 0; i < len(terms); i++ {
+			term := terms[i]
+
+			// allow whitespace between the operator and the version, e.g. ">= 1.2".
+			if strings.Trim(term, "<>=~^") == "" && i+1 < len(terms) {
+				i++
+				term += terms[i]
+			}
+
+			parsed, err := parseComparator(term)
+			if err != nil {
+				return Constraint{}, err
+			}
+			comparators = append(comparators, parsed...)
+		}
+
+		constraint.alternatives = append(constraint.alternatives, comparators)
+	}
+	return constraint, nil
+}
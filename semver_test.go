@@ -0,0 +1,88 @@
+package vld
+
+import (
+	"testing"
+)
+
+func TestVersionCompare(t *testing.T) {
+	// ordered by precedence as per the semver.org specification.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := 0; i+1 < len(ordered); i++ {
+		a, _ := ParseVersion(ordered[i])
+		b, _ := ParseVersion(ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", ordered[i], ordered[i+1])
+			return
+		}
+	}
+
+	a, _ := ParseVersion("1.0.0+build.1")
+	b, _ := ParseVersion("1.0.0+build.2")
+	if a.Compare(b) != 0 {
+		t.Error("build metadata must be ignored")
+		return
+	}
+}
+
+func TestParseConstraint(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		valid      []string
+		invalid    []string
+	}{
+		{constraint: "^1.2.3", valid: []string{"1.2.3", "1.9.0"}, invalid: []string{"1.2.2", "2.0.0"}},
+		{constraint: "^0.2.3", valid: []string{"0.2.3", "0.2.9"}, invalid: []string{"0.3.0"}},
+		{constraint: "^0.0.3", valid: []string{"0.0.3"}, invalid: []string{"0.0.4"}},
+		{constraint: "~1.2.3", valid: []string{"1.2.3", "1.2.9"}, invalid: []string{"1.3.0"}},
+		{constraint: "~1", valid: []string{"1.0.0", "1.9.0"}, invalid: []string{"2.0.0"}},
+		{constraint: "1.2", valid: []string{"1.2.0", "1.2.7"}, invalid: []string{"1.3.0"}},
+		{constraint: ">1.2", valid: []string{"1.3.0"}, invalid: []string{"1.2.9"}},
+		{constraint: "<=1.2", valid: []string{"1.2.9"}, invalid: []string{"1.3.0"}},
+		{constraint: "= 1.2.3", valid: []string{"1.2.3"}, invalid: []string{"1.2.4"}},
+		{constraint: "<1 || >=3", valid: []string{"0.9.0", "3.1.0"}, invalid: []string{"1.0.0", "2.9.9"}},
+	}
+
+	for _, testCase := range testCases {
+		constraint, err := ParseConstraint(testCase.constraint)
+		if err != nil {
+			t.Errorf("failed to parse constraint %s: %s", testCase.constraint, err.Error())
+			return
+		}
+
+		for _, input := range testCase.valid {
+			version, _ := ParseVersion(input)
+			if !constraint.Check(version) {
+				t.Errorf("%s must satisfy %s", input, testCase.constraint)
+				return
+			}
+		}
+
+		for _, input := range testCase.invalid {
+			version, _ := ParseVersion(input)
+			if constraint.Check(version) {
+				t.Errorf("%s must not satisfy %s", input, testCase.constraint)
+				return
+			}
+		}
+	}
+
+	for _, input := range []string{"", "1.2 ||", ">=abc", "~1.x"} {
+		if _, err := ParseConstraint(input); err == nil {
+			t.Errorf("invalid constraint parsed: %s", input)
+			return
+		}
+	}
+}
@@ -7,6 +7,7 @@ const (
 	CODE_MIN              = "min"
 	CODE_MAX              = "max"
 	CODE_LESS_THAN        = "less-than"
+	CODE_GREATER_THAN     = "greater-than"
 	CODE_EMAIL            = "email"
 	CODE_HAS_PREFIX       = "has-prefix"
 	CODE_HAS_SUFFIX       = "has-suffix"
@@ -22,9 +23,244 @@ const (
 	CODE_DATE_TIME        = "date-time"
 	CODE_DATE             = "date"
 	CODE_TIME             = "time"
-	CODE_DATE_EQUAL       = "date-equal"  // TODO: merge with `Equals`
-	CODE_DATE_BEFORE      = "date-before" // TODO: merge with `LessThan`
-	CODE_DATE_AFTER       = "date-after"  // TODO: merge with `GreaterThan`
+	CODE_DATE_EQUAL       = "date-equal"  // Deprecated: reported by `DateEqual` only, see `Equals`
+	CODE_DATE_BEFORE      = "date-before" // Deprecated: reported by `DateBefore` only, see `LessThan`
+	CODE_DATE_AFTER       = "date-after"  // Deprecated: reported by `DateAfter` only, see `GreaterThan`
 	CODE_LATITUDE         = "latitude"
 	CODE_LONGITUDE        = "longitude"
+	CODE_CREDIT_CARD      = "credit-card"
+	CODE_CVV              = "cvv"
+	CODE_CARD_EXPIRY      = "card-expiry"
+	CODE_IBAN             = "iban"
+	CODE_BIC              = "bic"
+	CODE_ROUTING_NUMBER   = "routing-number"
+	CODE_ULID             = "ulid"
+	CODE_KSUID            = "ksuid"
+	CODE_ISBN             = "isbn"
+	CODE_EAN              = "ean"
+	CODE_UPC              = "upc"
+	CODE_SEMVER           = "semver"
+	CODE_COUNTRY          = "country"
+	CODE_CURRENCY         = "currency"
+	CODE_LANGUAGE         = "language"
+	CODE_TIMEZONE         = "timezone"
+	CODE_SUBDIVISION      = "subdivision"
+	CODE_PHONE            = "phone"
+	CODE_POSTAL_CODE      = "postal-code"
+	CODE_INT              = "int"
+	CODE_FLOAT            = "float"
+	CODE_BOOL             = "bool"
+	CODE_DURATION         = "duration"
+	CODE_DECIMAL          = "decimal"
+	CODE_EACH             = "each"
+	CODE_MIN_ITEMS        = "min-items"
+	CODE_MAX_ITEMS        = "max-items"
+	CODE_UNIQUE           = "unique"
+	CODE_CONTAINS         = "contains"
+	CODE_SUBSET_OF        = "subset-of"
+	CODE_MAP              = "map"
+	CODE_KEYS             = "keys"
+	CODE_VALUES           = "values"
+	CODE_MIN_ENTRIES      = "min-entries"
+	CODE_MAX_ENTRIES      = "max-entries"
+	CODE_REQUIRED_KEY     = "required-key"
+	CODE_UNKNOWN_KEY      = "unknown-key"
+	CODE_UNION            = "union"
+	CODE_DECIMAL_DIGITS   = "decimal-digits"
+	CODE_DECIMAL_SCALE    = "decimal-scale"
+	CODE_MULTIPLE_OF      = "multiple-of"
+	CODE_MONEY            = "money"
+	CODE_IN_FUTURE        = "in-future"
+	CODE_IN_PAST          = "in-past"
+	CODE_WITHIN           = "within"
+	CODE_MIN_AGE          = "min-age"
+	CODE_MAX_AGE          = "max-age"
+	CODE_WEEKDAY          = "weekday"
+	CODE_BUSINESS_HOURS   = "business-hours"
+	CODE_CRON             = "cron"
+	CODE_ISO_DURATION     = "iso-duration"
+	CODE_RRULE            = "rrule"
+	CODE_MIN_INTERVAL     = "min-interval"
+	CODE_MAX_INTERVAL     = "max-interval"
+	CODE_ALPHA            = "alpha"
+	CODE_ALPHANUMERIC     = "alphanumeric"
+	CODE_ASCII            = "ascii"
+	CODE_PRINTABLE_ASCII  = "printable-ascii"
+	CODE_CONTROL_CHARS    = "control-chars"
+	CODE_SCRIPT           = "script"
+	CODE_MIXED_SCRIPTS    = "mixed-scripts"
+	CODE_SLUG             = "slug"
+	CODE_USERNAME         = "username"
+	CODE_IDENTIFIER       = "identifier"
+	CODE_RESERVED         = "reserved"
+	CODE_YAML             = "yaml"
+	CODE_XML              = "xml"
+	CODE_CSV              = "csv"
+	CODE_BASE64           = "base64"
+	CODE_HEX              = "hex"
+	CODE_DATA_URI         = "data-uri"
+	CODE_JWT              = "jwt"
+	CODE_JWT_ALGORITHM    = "jwt-algorithm"
+	CODE_JWT_SIGNATURE    = "jwt-signature"
+	CODE_JWT_EXPIRED      = "jwt-expired"
+	CODE_JWT_NOT_BEFORE   = "jwt-not-before"
+	CODE_JWT_ISSUED_AT    = "jwt-issued-at"
+	CODE_JWT_ISSUER       = "jwt-issuer"
+	CODE_JWT_AUDIENCE     = "jwt-audience"
+	CODE_HTML             = "html"
+	CODE_MAX_LINKS        = "max-links"
+	CODE_MAX_LINES        = "max-lines"
+	CODE_COORDINATES      = "coordinates"
+	CODE_BOUNDING_BOX     = "bounding-box"
+	CODE_DISTANCE         = "distance"
+	CODE_GEOJSON          = "geojson"
+	CODE_GEOHASH          = "geohash"
+	CODE_PLUS_CODE        = "plus-code"
+	CODE_MGRS             = "mgrs"
+	CODE_HEX_COLOR        = "hex-color"
+	CODE_COLOR            = "color"
+	CODE_MIME_TYPE        = "mime-type"
+	CODE_FILE_EXTENSION   = "file-extension"
+	CODE_SAFE_PATH        = "safe-path"
+	CODE_PATH_WITHIN      = "path-within"
+	CODE_FILENAME         = "filename"
+	CODE_PATH_DEPTH       = "path-depth"
+	CODE_PATH_EXISTS      = "path-exists"
+	CODE_SECRET           = "secret"
 )
+
+// Sentinel issues for each of the codes above. Issues are matched by code,
+// which allows checking the errors returned by rules and Validate e.g.
+// errors.Is(err, ErrEmail).
+var (
+	ErrUnknown        = newSentinel(CODE_UNKNOWN)
+	ErrNonEmptyString = newSentinel(CODE_NON_EMPTY_STRING)
+	ErrLength         = newSentinel(CODE_LENGTH)
+	ErrMin            = newSentinel(CODE_MIN)
+	ErrMax            = newSentinel(CODE_MAX)
+	ErrLessThan       = newSentinel(CODE_LESS_THAN)
+	ErrGreaterThan    = newSentinel(CODE_GREATER_THAN)
+	ErrEmail          = newSentinel(CODE_EMAIL)
+	ErrHasPrefix      = newSentinel(CODE_HAS_PREFIX)
+	ErrHasSuffix      = newSentinel(CODE_HAS_SUFFIX)
+	ErrNotHasPrefix   = newSentinel(CODE_NOT_HAS_PREFIX)
+	ErrNotHasSuffix   = newSentinel(CODE_NOT_HAS_SUFFIX)
+	ErrEquals         = newSentinel(CODE_EQUALS)
+	ErrEnum           = newSentinel(CODE_ENUM)
+	ErrURL            = newSentinel(CODE_URL)
+	ErrRegexp         = newSentinel(CODE_REGEXP)
+	ErrUUID           = newSentinel(CODE_UUID)
+	ErrPassword       = newSentinel(CODE_PASSWORD)
+	ErrJSON           = newSentinel(CODE_JSON)
+	ErrDateTime       = newSentinel(CODE_DATE_TIME)
+	ErrDate           = newSentinel(CODE_DATE)
+	ErrTime           = newSentinel(CODE_TIME)
+	ErrDateEqual      = newSentinel(CODE_DATE_EQUAL)
+	ErrDateBefore     = newSentinel(CODE_DATE_BEFORE)
+	ErrDateAfter      = newSentinel(CODE_DATE_AFTER)
+	ErrLatitude       = newSentinel(CODE_LATITUDE)
+	ErrLongitude      = newSentinel(CODE_LONGITUDE)
+	ErrCreditCard     = newSentinel(CODE_CREDIT_CARD)
+	ErrCVV            = newSentinel(CODE_CVV)
+	ErrCardExpiry     = newSentinel(CODE_CARD_EXPIRY)
+	ErrIBAN           = newSentinel(CODE_IBAN)
+	ErrBIC            = newSentinel(CODE_BIC)
+	ErrRoutingNumber  = newSentinel(CODE_ROUTING_NUMBER)
+	ErrULID           = newSentinel(CODE_ULID)
+	ErrKSUID          = newSentinel(CODE_KSUID)
+	ErrISBN           = newSentinel(CODE_ISBN)
+	ErrEAN            = newSentinel(CODE_EAN)
+	ErrUPC            = newSentinel(CODE_UPC)
+	ErrSemver         = newSentinel(CODE_SEMVER)
+	ErrCountry        = newSentinel(CODE_COUNTRY)
+	ErrCurrency       = newSentinel(CODE_CURRENCY)
+	ErrLanguage       = newSentinel(CODE_LANGUAGE)
+	ErrTimezone       = newSentinel(CODE_TIMEZONE)
+	ErrSubdivision    = newSentinel(CODE_SUBDIVISION)
+	ErrPhone          = newSentinel(CODE_PHONE)
+	ErrPostalCode     = newSentinel(CODE_POSTAL_CODE)
+	ErrInt            = newSentinel(CODE_INT)
+	ErrFloat          = newSentinel(CODE_FLOAT)
+	ErrBool           = newSentinel(CODE_BOOL)
+	ErrDuration       = newSentinel(CODE_DURATION)
+	ErrDecimal        = newSentinel(CODE_DECIMAL)
+	ErrEach           = newSentinel(CODE_EACH)
+	ErrMinItems       = newSentinel(CODE_MIN_ITEMS)
+	ErrMaxItems       = newSentinel(CODE_MAX_ITEMS)
+	ErrUnique         = newSentinel(CODE_UNIQUE)
+	ErrContains       = newSentinel(CODE_CONTAINS)
+	ErrSubsetOf       = newSentinel(CODE_SUBSET_OF)
+	ErrMap            = newSentinel(CODE_MAP)
+	ErrKeys           = newSentinel(CODE_KEYS)
+	ErrValues         = newSentinel(CODE_VALUES)
+	ErrMinEntries     = newSentinel(CODE_MIN_ENTRIES)
+	ErrMaxEntries     = newSentinel(CODE_MAX_ENTRIES)
+	ErrRequiredKey    = newSentinel(CODE_REQUIRED_KEY)
+	ErrUnknownKey     = newSentinel(CODE_UNKNOWN_KEY)
+	ErrUnion          = newSentinel(CODE_UNION)
+	ErrDecimalDigits  = newSentinel(CODE_DECIMAL_DIGITS)
+	ErrDecimalScale   = newSentinel(CODE_DECIMAL_SCALE)
+	ErrMultipleOf     = newSentinel(CODE_MULTIPLE_OF)
+	ErrMoney          = newSentinel(CODE_MONEY)
+	ErrInFuture       = newSentinel(CODE_IN_FUTURE)
+	ErrInPast         = newSentinel(CODE_IN_PAST)
+	ErrWithin         = newSentinel(CODE_WITHIN)
+	ErrMinAge         = newSentinel(CODE_MIN_AGE)
+	ErrMaxAge         = newSentinel(CODE_MAX_AGE)
+	ErrWeekday        = newSentinel(CODE_WEEKDAY)
+	ErrBusinessHours  = newSentinel(CODE_BUSINESS_HOURS)
+	ErrCron           = newSentinel(CODE_CRON)
+	ErrISODuration    = newSentinel(CODE_ISO_DURATION)
+	ErrRRule          = newSentinel(CODE_RRULE)
+	ErrMinInterval    = newSentinel(CODE_MIN_INTERVAL)
+	ErrMaxInterval    = newSentinel(CODE_MAX_INTERVAL)
+	ErrAlpha          = newSentinel(CODE_ALPHA)
+	ErrAlphanumeric   = newSentinel(CODE_ALPHANUMERIC)
+	ErrASCII          = newSentinel(CODE_ASCII)
+	ErrPrintableASCII = newSentinel(CODE_PRINTABLE_ASCII)
+	ErrControlChars   = newSentinel(CODE_CONTROL_CHARS)
+	ErrScript         = newSentinel(CODE_SCRIPT)
+	ErrMixedScripts   = newSentinel(CODE_MIXED_SCRIPTS)
+	ErrSlug           = newSentinel(CODE_SLUG)
+	ErrUsername       = newSentinel(CODE_USERNAME)
+	ErrIdentifier     = newSentinel(CODE_IDENTIFIER)
+	ErrReserved       = newSentinel(CODE_RESERVED)
+	ErrYAML           = newSentinel(CODE_YAML)
+	ErrXML            = newSentinel(CODE_XML)
+	ErrCSV            = newSentinel(CODE_CSV)
+	ErrBase64         = newSentinel(CODE_BASE64)
+	ErrHex            = newSentinel(CODE_HEX)
+	ErrDataURI        = newSentinel(CODE_DATA_URI)
+	ErrJWT            = newSentinel(CODE_JWT)
+	ErrJWTAlgorithm   = newSentinel(CODE_JWT_ALGORITHM)
+	ErrJWTSignature   = newSentinel(CODE_JWT_SIGNATURE)
+	ErrJWTExpired     = newSentinel(CODE_JWT_EXPIRED)
+	ErrJWTNotBefore   = newSentinel(CODE_JWT_NOT_BEFORE)
+	ErrJWTIssuedAt    = newSentinel(CODE_JWT_ISSUED_AT)
+	ErrJWTIssuer      = newSentinel(CODE_JWT_ISSUER)
+	ErrJWTAudience    = newSentinel(CODE_JWT_AUDIENCE)
+	ErrHTML           = newSentinel(CODE_HTML)
+	ErrMaxLinks       = newSentinel(CODE_MAX_LINKS)
+	ErrMaxLines       = newSentinel(CODE_MAX_LINES)
+	ErrCoordinates    = newSentinel(CODE_COORDINATES)
+	ErrBoundingBox    = newSentinel(CODE_BOUNDING_BOX)
+	ErrDistance       = newSentinel(CODE_DISTANCE)
+	ErrGeoJSON        = newSentinel(CODE_GEOJSON)
+	ErrGeohash        = newSentinel(CODE_GEOHASH)
+	ErrPlusCode       = newSentinel(CODE_PLUS_CODE)
+	ErrMGRS           = newSentinel(CODE_MGRS)
+	ErrHexColor       = newSentinel(CODE_HEX_COLOR)
+	ErrColor          = newSentinel(CODE_COLOR)
+	ErrMIMEType       = newSentinel(CODE_MIME_TYPE)
+	ErrFileExtension  = newSentinel(CODE_FILE_EXTENSION)
+	ErrSafePath       = newSentinel(CODE_SAFE_PATH)
+	ErrPathWithin     = newSentinel(CODE_PATH_WITHIN)
+	ErrFilename       = newSentinel(CODE_FILENAME)
+	ErrPathDepth      = newSentinel(CODE_PATH_DEPTH)
+	ErrPathExists     = newSentinel(CODE_PATH_EXISTS)
+	ErrSecret         = newSentinel(CODE_SECRET)
+)
+
+func newSentinel(code string) *Issue {
+	return &Issue{Code: code, Message: code}
+}
@@ -1,22 +1,177 @@
 package vld
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SEVERITY_ERROR   = "error"
+	SEVERITY_WARNING = "warning"
+	SEVERITY_INFO    = "info"
+)
+
 type Issue struct {
 	Code    string
 	Message string
 	Value   any
+
+	// Severity is one of the SEVERITY_* values. Issues without a severity are
+	// treated as errors. Only errors cause validation to fail, warnings and
+	// info issues are advisory.
+	Severity string
+
+	// Path locates the issue within the validated data, relative to the tag of
+	// the validation e.g. "[3]" for the fourth element of a slice. Issues are
+	// reported under the tag joined with their path e.g. "tags[3]". It's empty
+	// for issues about the data as a whole.
+	Path string
 }
 
 func (issue Issue) Error() string {
 	return issue.Message
 }
 
+// REDACTED replaces the values of the issues reported for sensitive data.
+const REDACTED = "[REDACTED]"
+
+// REDACTED_MESSAGE replaces the messages of the issues reported for
+// sensitive data which contain an input too short to be replaced safely.
+const REDACTED_MESSAGE = "The value is invalid"
+
+// redactedMinLength is the length below which inputs aren't replaced in
+// messages, since they would match parts of the message rather than the
+// input. Messages containing such inputs are replaced as a whole.
+const redactedMinLength = 4
+
+// redact returns the issue with its value replaced by REDACTED, and the
+// provided inputs replaced by REDACTED in its message, regardless of their
+// case. Inputs which aren't strings are matched in their fmt.Sprint form, and
+// a []byte as a string as well.
+func (issue Issue) redact(inputs ...any) Issue {
+	if issue.Value != nil {
+		issue.Value = REDACTED
+	}
+
+	var texts []string
+	for _, input := range inputs {
+		switch t := input.(type) {
+		case nil:
+		case string:
+			texts = append(texts, t)
+		case []byte:
+			texts = append(texts, string(t), fmt.Sprint(t))
+		default:
+			texts = append(texts, fmt.Sprint(t))
+		}
+	}
+
+	for _, text := range texts {
+		redacted := replaceFold(issue.Message, text, REDACTED)
+		if redacted != issue.Message && len(text) < redactedMinLength {
+			issue.Message = REDACTED_MESSAGE
+			break
+		}
+		issue.Message = redacted
+	}
+	return issue
+}
+
+// replaceFold replaces the occurrences of old in the provided text by new,
+// regardless of their case.
+func replaceFold(text string, old string, new string) string {
+	if old == "" {
+		return text
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(text); {
+		if i+len(old) <= len(text) && strings.EqualFold(text[i:i+len(old)], old) {
+			builder.WriteString(new)
+			i += len(old)
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(text[i:])
+		builder.WriteString(text[i : i+size])
+		i += size
+	}
+	return builder.String()
+}
+
+// blocking reports whether the issue causes the validation to fail.
+func (issue Issue) blocking() bool {
+	return issue.Severity == "" || issue.Severity == SEVERITY_ERROR
+}
+
+// Is reports whether the target is an issue with the same code. This allows
+// matching issues against the sentinels e.g. errors.Is(err, ErrEmail).
+func (issue Issue) Is(target error) bool {
+	switch t := target.(type) {
+	case Issue:
+		return t.Code == issue.Code
+	case *Issue:
+		return t != nil && t.Code == issue.Code
+	}
+	return false
+}
+
 // The `Issue` struct implements the error interface, which makes it tricky
 // to serialize. This `IssueDTO` struct is required because we do need
 // serialization of the issues.
 type IssueDTO struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Value   any    `json:"value"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Value    any    `json:"value"`
+	Severity string `json:"severity"`
+
+	// Issues lists every issue reported for the field, when a rule reported
+	// more than one e.g. using errors.Join. The first of them is also used as
+	// the code, message and value of the DTO itself.
+	Issues []IssueDTO `json:"issues,omitempty"`
+}
+
+func newIssueDTO(issue Issue) IssueDTO {
+	severity := issue.Severity
+	if severity == "" {
+		severity = SEVERITY_ERROR
+	}
+
+	return IssueDTO{
+		Code:     issue.Code,
+		Message:  issue.Message,
+		Value:    issue.Value,
+		Severity: severity,
+	}
+}
+
+// addIssues records the provided issues for a tag, each under the tag joined
+// with the path of the issue. The first issue recorded for a key is used for
+// the DTO itself, and once there is more than one, all of them are listed in
+// its Issues.
+func addIssues(dtos map[string]IssueDTO, tag string, issues []Issue) {
+	for _, issue := range issues {
+		key := tag + issue.Path
+
+		var all []IssueDTO
+		if existing, ok := dtos[key]; ok {
+			all = existing.Issues
+			if len(all) == 0 {
+				all = []IssueDTO{existing}
+			}
+		}
+		all = append(all, newIssueDTO(issue))
+
+		dto := all[0]
+		if len(all) > 1 {
+			dto.Issues = all
+		}
+		dtos[key] = dto
+	}
 }
 
 type Rule func(any) (any, error)
@@ -25,48 +180,360 @@ type Validation struct {
 	Tag   string
 	Data  any
 	Rules []Rule
+
+	// Output is an optional pointer which receives the output of the last
+	// rule, e.g. the trimmed string or the parsed time.Time, once all the
+	// validations have passed.
+	Output any
+
+	// Sensitive marks the data as secret, e.g. a password or a token. The
+	// issues reported for it are redacted (see Sensitive), while the output
+	// is left as it is.
+	Sensitive bool
 }
 
 type ValidationErrors struct {
 	Errors map[string]IssueDTO `json:"errors"`
+
+	// Warnings holds the advisory issues of all validations, including the
+	// ones which passed.
+	Warnings map[string]IssueDTO `json:"warnings,omitempty"`
+}
+
+// ValidationWarnings holds the advisory issues, i.e. warnings and info
+// issues, reported while validating. They never cause validation to fail.
+type ValidationWarnings struct {
+	Warnings map[string]IssueDTO `json:"warnings"`
 }
 
 func (v ValidationErrors) Error() string {
 	return "Validation of provided data failed"
 }
 
+// Is reports whether any of the fields failed with an issue matching the
+// target, e.g. errors.Is(err, ErrEmail).
+func (v ValidationErrors) Is(target error) bool {
+	for _, dto := range v.Errors {
+		for _, issue := range append([]IssueDTO{dto}, dto.Issues...) {
+			if (Issue{Code: issue.Code}).Is(target) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ConfigError is returned by rules which were set up incorrectly, e.g.
+// Min("3"). Unlike issues, config errors are caused by the programmer rather
+// than the validated data, which is why Validate returns them as they are
+// instead of reporting them as ValidationErrors.
+type ConfigError struct {
+	Tag     string
+	Rule    string
+	Message string
+}
+
+func (e ConfigError) Error() string {
+	if e.Tag == "" {
+		return fmt.Sprintf("vld: invalid %s rule: %s", e.Rule, e.Message)
+	}
+	return fmt.Sprintf("vld: invalid %s rule for '%s': %s", e.Rule, e.Tag, e.Message)
+}
+
+// asConfigError returns the ConfigError wrapped by err, with its tag set to
+// the tag of the validation it was returned for.
+func asConfigError(err error, tag string) (ConfigError, bool) {
+	var errConfig ConfigError
+	if !errors.As(err, &errConfig) {
+		return ConfigError{}, false
+	}
+
+	if errConfig.Tag == "" {
+		errConfig.Tag = tag
+	}
+	return errConfig, true
+}
+
+// Issues extracts the issues from an error returned by a rule using
+// errors.As. Issues may be returned as values or pointers, wrapped using
+// fmt.Errorf("%w"), combined using errors.Join, or found by an error type
+// implementing As(any) bool. Errors which don't wrap an issue are reported
+// with the CODE_UNKNOWN code.
+func Issues(err error) []Issue {
+	if err == nil {
+		return nil
+	}
+
+	// errors.As only finds the first of the joined errors, so each of them is
+	// extracted on its own, even when the join is wrapped.
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		var issues []Issue
+		for _, inner := range joined.Unwrap() {
+			issues = append(issues, Issues(inner)...)
+		}
+		return issues
+	}
+
+	var issue Issue
+	if errors.As(err, &issue) {
+		return []Issue{issue}
+	}
+
+	var pointer *Issue
+	if errors.As(err, &pointer) {
+		if pointer == nil {
+			return nil
+		}
+		return []Issue{*pointer}
+	}
+
+	return []Issue{{
+		Code:    CODE_UNKNOWN,
+		Message: err.Error(),
+	}}
+}
+
+// Validate runs the rules of each validation against its data. Rules of a
+// validation are run in order, the output of each rule being the input of the
+// next one, until a rule fails. The first issue of every failing validation is
+// returned as ValidationErrors. When a rule reports a ConfigError, validation
+// stops and the ConfigError is returned instead. Advisory issues don't cause
+// validation to fail, use ValidateWithWarnings to receive them.
 func Validate(validations []Validation) error {
+	_, _, err := validate(validations)
+	return err
+}
+
+// ValidateWithWarnings validates the same way as Validate, and also returns
+// the advisory issues (see Warn) reported along the way. When a rule reports
+// an advisory issue, its output is passed on to the next rule as usual.
+func ValidateWithWarnings(validations []Validation) (ValidationWarnings, error) {
+	_, warnings, err := validate(validations)
+	return warnings, err
+}
+
+// ValidateValues validates the same way as Validate, and also returns the
+// output of the last rule of each validation keyed by tag. Transformers such
+// as TrimSpace and parsing rules such as Date change the output, which means
+// the returned values are the cleaned-up data that should be persisted. No
+// values are returned when validation fails.
+func ValidateValues(validations []Validation) (map[string]any, error) {
+	values, _, err := validate(validations)
+	return values, err
+}
+
+func validate(validations []Validation) (map[string]any, ValidationWarnings, error) {
+	values := make(map[string]any)
 	errors := ValidationErrors{
 		Errors: make(map[string]IssueDTO),
 	}
+	warnings := ValidationWarnings{
+		Warnings: make(map[string]IssueDTO),
+	}
 
+	failed := make(map[string]bool)
 	for _, validation := range validations {
-		if _, exists := errors.Errors[validation.Tag]; exists {
+		if failed[validation.Tag] {
 			continue
 		}
 
 		data := validation.Data
-		var err error
-
 		for _, rule := range validation.Rules {
-			data, err = rule(data)
-			if err != nil {
-				validationIssue, ok := err.(Issue)
-				if ok {
-					errors.Errors[validation.Tag] = IssueDTO(validationIssue)
+			output, err := rule(data)
+			if err == nil {
+				data = output
+				continue
+			}
+
+			if errConfig, ok := asConfigError(err, validation.Tag); ok {
+				return nil, warnings, errConfig
+			}
+
+			var blocking, advisory []Issue
+			for _, issue := range Issues(err) {
+				if validation.Sensitive {
+					issue = issue.redact(validation.Data, data)
+				}
+
+				if issue.blocking() {
+					blocking = append(blocking, issue)
 				} else {
-					errors.Errors[validation.Tag] = IssueDTO{
-						Code:    CODE_UNKNOWN,
-						Message: err.Error(),
-					}
+					advisory = append(advisory, issue)
 				}
 			}
+
+			if len(advisory) != 0 {
+				addIssues(warnings.Warnings, validation.Tag, advisory)
+			}
+
+			if len(blocking) != 0 {
+				addIssues(errors.Errors, validation.Tag, blocking)
+				failed[validation.Tag] = true
+				break
+			}
+
+			// rules failing with advisory issues only may not return an
+			// output, in which case the input is passed on unchanged.
+			if output != nil {
+				data = output
+			}
 		}
+
+		values[validation.Tag] = data
 	}
 
 	if len(errors.Errors) != 0 {
-		return errors
+		if len(warnings.Warnings) != 0 {
+			errors.Warnings = warnings.Warnings
+		}
+		return nil, warnings, errors
+	}
+
+	for _, validation := range validations {
+		if validation.Output == nil {
+			continue
+		}
+
+		if err := assignOutput(validation.Output, values[validation.Tag]); err != nil {
+			return nil, warnings, ConfigError{Tag: validation.Tag, Rule: "Output", Message: err.Error()}
+		}
 	}
 
+	return values, warnings, nil
+}
+
+// assignOutput stores the value in the variable the output pointer points to.
+func assignOutput(output any, value any) error {
+	target := reflect.ValueOf(output)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("output must be a non-nil pointer, got %T", output)
+	}
+
+	target = target.Elem()
+	if value == nil {
+		target.SetZero()
+		return nil
+	}
+
+	// named types with the same underlying kind are converted, e.g. a string
+	// can be stored in a variable of type `type Email string`.
+	source := reflect.ValueOf(value)
+	switch {
+	case source.Type().AssignableTo(target.Type()):
+		target.Set(source)
+	case source.Kind() == target.Kind() && source.Type().ConvertibleTo(target.Type()):
+		target.Set(source.Convert(target.Type()))
+	default:
+		return fmt.Errorf("cannot assign %T to %s", value, target.Type())
+	}
 	return nil
 }
+
+// WithSeverity changes the severity of the issues reported by the provided
+// rule, e.g. to SEVERITY_WARNING. When the severity is advisory and the rule
+// fails, its input is passed on unchanged to the next rule.
+func WithSeverity(severity string, rule Rule) Rule {
+	return func(input any) (any, error) {
+		output, err := rule(input)
+		if err == nil {
+			return output, nil
+		}
+
+		if _, ok := asConfigError(err, ""); ok {
+			return nil, err
+		}
+
+		issues := Issues(err)
+		errs := make([]error, len(issues))
+		for i, issue := range issues {
+			issue.Severity = severity
+			errs[i] = issue
+		}
+
+		if !(Issue{Severity: severity}).blocking() {
+			output = input
+		}
+
+		if len(errs) == 1 {
+			return output, errs[0]
+		}
+		return output, errors.Join(errs...)
+	}
+}
+
+// Sensitive redacts the issues reported by the provided rule, e.g.
+// Sensitive(Equals("password", password)), so that they can be serialized
+// and logged without leaking secrets: their value is replaced by REDACTED, as
+// well as the input when it's part of their message. Inputs which aren't
+// strings, e.g. a numeric PIN, are matched in their fmt.Sprint form. Messages
+// containing an input shorter than 4 bytes are replaced by REDACTED_MESSAGE,
+// since such inputs can't be told apart from the rest of the message. See
+// Validation.Sensitive to redact the issues of all the rules of a validation.
+func Sensitive(rule Rule) Rule {
+	return func(input any) (any, error) {
+		output, err := rule(input)
+		if err == nil {
+			return output, nil
+		}
+
+		if _, ok := asConfigError(err, ""); ok {
+			return nil, err
+		}
+
+		issues := Issues(err)
+		errs := make([]error, len(issues))
+		for i, issue := range issues {
+			errs[i] = issue.redact(input)
+		}
+
+		if len(errs) == 1 {
+			return output, errs[0]
+		}
+		return output, errors.Join(errs...)
+	}
+}
+
+// Warn turns the issues reported by the provided rule into warnings, which
+// don't cause validation to fail e.g. Warn(Min(fiveYearsAgo)).
+func Warn(rule Rule) Rule {
+	return WithSeverity(SEVERITY_WARNING, rule)
+}
+
+// Check is a dry-run of Validate which only reports ConfigErrors, and is meant
+// to be called from unit tests to catch misconfigured rules. Every rule is run
+// against nil, which is enough for rules that check their configuration on
+// construction, and the rules of each validation are also run against its
+// data as Validate would. Issues about the data itself are ignored. All
+// config errors found are returned combined using errors.Join.
+func Check(validations []Validation) error {
+	var errs []error
+	for _, validation := range validations {
+		for _, rule := range validation.Rules {
+			if _, err := rule(nil); err != nil {
+				if errConfig, ok := asConfigError(err, validation.Tag); ok {
+					errs = append(errs, errConfig)
+				}
+			}
+		}
+
+		data := validation.Data
+		for _, rule := range validation.Rules {
+			var err error
+			data, err = rule(data)
+			if err == nil {
+				continue
+			}
+
+			if errConfig, ok := asConfigError(err, validation.Tag); ok && !slices.Contains(errs, error(errConfig)) {
+				errs = append(errs, errConfig)
+			}
+
+			if slices.ContainsFunc(Issues(err), Issue.blocking) {
+				break
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -1,7 +1,12 @@
 package vld
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoginFormExample(t *testing.T) {
@@ -61,3 +66,509 @@ func TestResetPasswordExample(t *testing.T) {
 		return
 	}
 }
+
+func TestCheckoutFormExample(t *testing.T) {
+	form := struct {
+		Country    string
+		PostalCode string
+	}{
+		Country:    "CA",
+		PostalCode: "90210",
+	}
+
+	validations := []Validation{
+		{
+			Tag:   "country",
+			Data:  form.Country,
+			Rules: []Rule{CountryAlpha2},
+		},
+		{
+			Tag:   "postal_code",
+			Data:  form.PostalCode,
+			Rules: []Rule{NonEmptyString, PostalCode(form.Country)},
+		},
+	}
+
+	err := Validate(validations)
+	if err == nil {
+		t.Error("invalid data returned as valid")
+		return
+	}
+
+	validationErrors := err.(ValidationErrors)
+	if validationErrors.Errors["postal_code"].Code != CODE_POSTAL_CODE {
+		t.Error("postal code issue not reported")
+		return
+	}
+}
+
+func TestValidatePointerIssue(t *testing.T) {
+	validations := []Validation{
+		{
+			Tag:   "name",
+			Data:  "",
+			Rules: []Rule{NonEmptyString, Length(5)},
+		},
+	}
+
+	err := Validate(validations)
+	validationErrors, ok := err.(ValidationErrors)
+	if !ok {
+		t.Error("invalid data returned as valid")
+		return
+	}
+
+	if validationErrors.Errors["name"].Code != CODE_NON_EMPTY_STRING {
+		t.Errorf("unexpected code %s", validationErrors.Errors["name"].Code)
+		return
+	}
+}
+
+func TestValidateWrappedIssue(t *testing.T) {
+	wrapped := func(input any) (any, error) {
+		v, err := Email(input)
+		if err != nil {
+			return nil, fmt.Errorf("checking contact address: %w", err)
+		}
+		return v, nil
+	}
+
+	err := Validate([]Validation{{Tag: "email", Data: "invalid", Rules: []Rule{wrapped}}})
+	if err == nil {
+		t.Error("invalid data returned as valid")
+		return
+	}
+
+	if err.(ValidationErrors).Errors["email"].Code != CODE_EMAIL {
+		t.Error("wrapped issue code lost")
+		return
+	}
+
+	if !errors.Is(err, ErrEmail) || errors.Is(err, ErrURL) {
+		t.Error("validation errors must match issue sentinels")
+		return
+	}
+}
+
+func TestValidateJoinedIssues(t *testing.T) {
+	multiple := func(input any) (any, error) {
+		_, errPrefix := HasPrefix("https://")(input)
+		_, errSuffix := HasSuffix(".com")(input)
+		return nil, errors.Join(errPrefix, errSuffix)
+	}
+
+	err := Validate([]Validation{{Tag: "website", Data: "ftp://site.org", Rules: []Rule{multiple}}})
+	if err == nil {
+		t.Error("invalid data returned as valid")
+		return
+	}
+
+	dto := err.(ValidationErrors).Errors["website"]
+	if dto.Code != CODE_HAS_PREFIX || len(dto.Issues) != 2 || dto.Issues[1].Code != CODE_HAS_SUFFIX {
+		t.Error("joined issues not reported")
+		return
+	}
+
+	if !errors.Is(err, ErrHasSuffix) {
+		t.Error("validation errors must match joined issue sentinels")
+		return
+	}
+}
+
+func TestIssueSentinels(t *testing.T) {
+	_, err := NonEmptyString("")
+	if !errors.Is(err, ErrNonEmptyString) || errors.Is(err, ErrLength) {
+		t.Error("pointer issue must match its sentinel only")
+		return
+	}
+
+	_, err = Email("invalid")
+	if !errors.Is(fmt.Errorf("wrapped: %w", err), ErrEmail) {
+		t.Error("wrapped issue must match its sentinel")
+		return
+	}
+
+	issues := Issues(errors.New("plain error"))
+	if len(issues) != 1 || issues[0].Code != CODE_UNKNOWN || issues[0].Message != "plain error" {
+		t.Error("plain errors must be reported as unknown issues")
+		return
+	}
+}
+
+// issueHolder is an error type exposing its issue through an As method
+// rather than wrapping it.
+type issueHolder struct{ code string }
+
+func (e issueHolder) Error() string { return "holder: " + e.code }
+
+func (e issueHolder) As(target any) bool {
+	issue, ok := target.(*Issue)
+	if ok {
+		*issue = Issue{Code: e.code, Message: "Held issue"}
+	}
+	return ok
+}
+
+func TestIssuesAs(t *testing.T) {
+	issues := Issues(fmt.Errorf("checking: %w", issueHolder{code: "held"}))
+	if len(issues) != 1 || issues[0].Code != "held" {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+
+	_, errPrefix := HasPrefix("https://")("ftp://site.org")
+	_, errSuffix := HasSuffix(".com")("ftp://site.org")
+	issues = Issues(fmt.Errorf("checking: %w", errors.Join(errPrefix, errSuffix)))
+	if len(issues) != 2 || issues[1].Code != CODE_HAS_SUFFIX {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+}
+
+func TestWithCode(t *testing.T) {
+	rules := []Rule{
+		func(input any) (any, error) { return nil, &Issue{Code: "pointer"} },
+		func(input any) (any, error) { return nil, fmt.Errorf("wrapped: %w", Issue{Code: "wrapped"}) },
+		func(input any) (any, error) { return nil, issueHolder{code: "held"} },
+	}
+
+	for _, rule := range rules {
+		_, err := withCode(CODE_DATE_AFTER, rule)(nil)
+		if issues := Issues(err); len(issues) != 1 || issues[0].Code != CODE_DATE_AFTER {
+			t.Errorf("unexpected issues %v", issues)
+			return
+		}
+	}
+}
+
+func TestValidateConfigError(t *testing.T) {
+	validations := []Validation{
+		{
+			Tag:   "age",
+			Data:  20,
+			Rules: []Rule{Min("3")},
+		},
+	}
+
+	err := Validate(validations)
+	errConfig, ok := err.(ConfigError)
+	if !ok {
+		t.Errorf("expected config error, got %v", err)
+		return
+	}
+
+	if errConfig.Tag != "age" || errConfig.Rule != "Min" {
+		t.Errorf("unexpected config error %s", errConfig.Error())
+		return
+	}
+}
+
+func TestCheck(t *testing.T) {
+	valid := []Validation{
+		{Tag: "email", Data: "", Rules: []Rule{NonEmptyString, Email}},
+		{Tag: "age", Data: 20, Rules: []Rule{Min(18), Max(99.5)}},
+	}
+
+	if err := Check(valid); err != nil {
+		t.Errorf("valid setup reported as invalid: %s", err.Error())
+		return
+	}
+
+	invalid := []Validation{
+		{Tag: "age", Data: 20, Rules: []Rule{Min("3")}},
+		{Tag: "name", Data: "", Rules: []Rule{NonEmptyString, Max(2.5)}},
+		{Tag: "slug", Data: "abc", Rules: []Rule{Regexp("[a-z")}},
+		{Tag: "bio", Data: "abc", Rules: []Rule{Max(2.5)}},
+	}
+
+	err := Check(invalid)
+	if err == nil {
+		t.Error("invalid setup reported as valid")
+		return
+	}
+
+	var tags []string
+	for _, inner := range err.(interface{ Unwrap() []error }).Unwrap() {
+		tags = append(tags, inner.(ConfigError).Tag)
+	}
+
+	if fmt.Sprint(tags) != "[age slug bio]" {
+		t.Errorf("unexpected config errors: %v", tags)
+		return
+	}
+}
+
+func TestValidateWithWarnings(t *testing.T) {
+	fiveYearsAgo := time.Now().AddDate(-5, 0, 0)
+	validations := []Validation{
+		{
+			Tag:   "email",
+			Data:  "admin@gmial.com",
+			Rules: []Rule{Email, Warn(NotHasSuffix("@gmial.com")), HasSuffix(".com")},
+		},
+		{
+			Tag:   "joined_at",
+			Data:  "2001-02-03",
+			Rules: []Rule{Date, Warn(Min(fiveYearsAgo))},
+		},
+	}
+
+	warnings, err := ValidateWithWarnings(validations)
+	if err != nil {
+		t.Errorf("advisory issues must not fail validation: %v", err)
+		return
+	}
+
+	if len(warnings.Warnings) != 2 || warnings.Warnings["email"].Severity != SEVERITY_WARNING {
+		t.Errorf("unexpected warnings: %v", warnings.Warnings)
+		return
+	}
+
+	if err := Validate(validations); err != nil {
+		t.Error("advisory issues must not fail validation")
+		return
+	}
+}
+
+func TestValidateAdvisoryIssueKeepsData(t *testing.T) {
+	// custom rules usually return a nil output along with their issue.
+	nudge := func(input any) (any, error) {
+		return nil, Issue{Code: "nudge", Message: "Consider a shorter name", Severity: SEVERITY_WARNING}
+	}
+
+	var name string
+	validations := []Validation{
+		{
+			Tag:    "name",
+			Data:   " Gopher ",
+			Rules:  []Rule{nudge, TrimSpace, NonEmptyString},
+			Output: &name,
+		},
+	}
+
+	warnings, err := ValidateWithWarnings(validations)
+	if err != nil {
+		t.Errorf(errValidFailed, err.Error())
+		return
+	}
+
+	if len(warnings.Warnings) != 1 || name != "Gopher" {
+		t.Errorf("unexpected output %q with warnings %v", name, warnings.Warnings)
+		return
+	}
+}
+
+func TestValidationErrorsIncludeWarnings(t *testing.T) {
+	validations := []Validation{
+		{
+			Tag:   "email",
+			Data:  "admin@gmial.com",
+			Rules: []Rule{Warn(NotHasSuffix("@gmial.com"))},
+		},
+		{
+			Tag:   "password",
+			Data:  "abc",
+			Rules: []Rule{Min(8)},
+		},
+	}
+
+	err := Validate(validations)
+	validationErrors, ok := err.(ValidationErrors)
+	if !ok {
+		t.Error("invalid data returned as valid")
+		return
+	}
+
+	if validationErrors.Errors["password"].Severity != SEVERITY_ERROR {
+		t.Error("blocking issues must have the error severity")
+		return
+	}
+
+	encoded, _ := json.Marshal(validationErrors)
+	if !strings.Contains(string(encoded), `"warnings":{"email":{"code":"not-has-suffix"`) {
+		t.Errorf("warnings missing from encoded errors: %s", encoded)
+		return
+	}
+}
+
+func TestValidateValues(t *testing.T) {
+	type Address string
+
+	var email Address
+	var joinedAt time.Time
+
+	validations := []Validation{
+		{
+			Tag:    "email",
+			Data:   "  Admin@Site.com ",
+			Rules:  []Rule{TrimSpace, Lowercase, Email},
+			Output: &email,
+		},
+		{
+			Tag:    "joined_at",
+			Data:   "2024-03-22",
+			Rules:  []Rule{Date},
+			Output: &joinedAt,
+		},
+		{
+			Tag:   "locale",
+			Data:  "",
+			Rules: []Rule{Default("en-US"), LanguageTag},
+		},
+	}
+
+	values, err := ValidateValues(validations)
+	if err != nil {
+		t.Errorf("valid data returned as invalid: %v", err)
+		return
+	}
+
+	if values["email"] != "admin@site.com" || values["locale"] != "en-US" {
+		t.Errorf("unexpected values: %v", values)
+		return
+	}
+
+	if email != "admin@site.com" || joinedAt.Year() != 2024 {
+		t.Error("values not written to outputs")
+		return
+	}
+}
+
+func TestValidateOutputNotWrittenOnFailure(t *testing.T) {
+	name := "unchanged"
+	validations := []Validation{
+		{Tag: "name", Data: " John ", Rules: []Rule{TrimSpace}, Output: &name},
+		{Tag: "email", Data: "invalid", Rules: []Rule{Email}},
+	}
+
+	values, err := ValidateValues(validations)
+	if err == nil || values != nil || name != "unchanged" {
+		t.Error("outputs must not be written when validation fails")
+		return
+	}
+}
+
+func TestValidateOutputTypeMismatch(t *testing.T) {
+	var count int
+	err := Validate([]Validation{{Tag: "name", Data: "John", Rules: []Rule{TrimSpace}, Output: &count}})
+	if _, ok := err.(ConfigError); !ok {
+		t.Errorf("expected config error, got %v", err)
+		return
+	}
+}
+
+func TestValidateElementPaths(t *testing.T) {
+	validations := []Validation{
+		{
+			Tag:   "tags",
+			Data:  []string{"go", "", "rust", "go"},
+			Rules: []Rule{MaxItems(5), Each(NonEmptyString), Unique},
+		},
+		{
+			Tag:   "tags",
+			Data:  []string{"go"},
+			Rules: []Rule{MinItems(5)},
+		},
+	}
+
+	err := Validate(validations)
+	var errValidation ValidationErrors
+	if !errors.As(err, &errValidation) {
+		t.Error("invalid data returned as valid")
+		return
+	}
+
+	if len(errValidation.Errors) != 1 || errValidation.Errors["tags[1]"].Code != CODE_NON_EMPTY_STRING {
+		t.Errorf("unexpected errors: %v", errValidation.Errors)
+		return
+	}
+}
+
+func TestSensitive(t *testing.T) {
+	rule := Sensitive(Username(DefaultUsernameFormat))
+	_, err := rule("Admin")
+	issues := Issues(err)
+	if len(issues) != 1 || issues[0].Code != CODE_RESERVED {
+		t.Errorf("unexpected issues %v", issues)
+		return
+	}
+
+	if issues[0].Value != REDACTED || strings.Contains(issues[0].Error(), "dmin") {
+		t.Errorf("issue not redacted: %v", issues[0])
+		return
+	}
+
+	if v, err := rule("john.doe"); err != nil || v != "john.doe" {
+		t.Error("outputs of sensitive rules must be left as they are")
+		return
+	}
+
+	echo := func(input any) (any, error) {
+		return nil, Issue{Code: "echo", Message: fmt.Sprintf("The value %v is not allowed", input)}
+	}
+
+	testCases := []struct {
+		input    any
+		expected string
+	}{
+		{input: []byte("tok-secret"), expected: "The value [REDACTED] is not allowed"},
+		{input: 4321, expected: "The value [REDACTED] is not allowed"},
+		{input: "ab", expected: REDACTED_MESSAGE},
+		{input: 42, expected: REDACTED_MESSAGE},
+	}
+
+	for _, testCase := range testCases {
+		_, err := Sensitive(echo)(testCase.input)
+		if message := err.Error(); message != testCase.expected {
+			t.Errorf("issue not redacted for %v: %s", testCase.input, message)
+			return
+		}
+	}
+
+	var errConfig ConfigError
+	if _, err := Sensitive(Min("3"))(nil); !errors.As(err, &errConfig) {
+		t.Error("config errors must be returned as they are")
+		return
+	}
+}
+
+func TestValidateSensitive(t *testing.T) {
+	var password string
+	validations := []Validation{
+		{
+			Tag:       "password",
+			Data:      "hunter2-hunter2",
+			Rules:     []Rule{Min(8), Equals("confirmation", "hunter2-hunter3")},
+			Sensitive: true,
+		},
+		{
+			Tag:   "api_key",
+			Data:  "sk_live_0123456789abcdefghij",
+			Rules: []Rule{Enum("a", "b")},
+		},
+	}
+
+	err := Validate(validations)
+	var errValidation ValidationErrors
+	if !errors.As(err, &errValidation) {
+		t.Error("invalid data returned as valid")
+		return
+	}
+
+	if dto := errValidation.Errors["password"]; dto.Code != CODE_EQUALS || dto.Value != REDACTED {
+		t.Errorf("issue not redacted: %v", dto)
+		return
+	}
+
+	if dto := errValidation.Errors["api_key"]; dto.Value == REDACTED {
+		t.Error("issues of validations which aren't sensitive must not be redacted")
+		return
+	}
+
+	validations[0].Rules = []Rule{Min(8)}
+	validations[0].Output = &password
+	if err := Validate(validations[:1]); err != nil || password != "hunter2-hunter2" {
+		t.Error("outputs of sensitive validations must be left as they are")
+		return
+	}
+}